@@ -2,5 +2,4 @@ package config
 
 const (
 	GRPCUserServiceAddr = "localhost:8081"
-	JWTSecretKey        = "your_jwt_secret_key"
 )
@@ -0,0 +1,19 @@
+package config
+
+var (
+	// UserServiceTLSCAFile enables TLS towards user-service, trusting the
+	// CAs in the file. UserServiceTLSCertFile and UserServiceTLSKeyFile are
+	// the client certificate for mutual TLS, UserServiceTLSServerName
+	// overrides the name the server certificate is checked against.
+	UserServiceTLSCAFile     string
+	UserServiceTLSCertFile   string
+	UserServiceTLSKeyFile    string
+	UserServiceTLSServerName string
+)
+
+func InitGRPC() {
+	UserServiceTLSCAFile = getEnv("USER_SERVICE_TLS_CA_FILE", "")
+	UserServiceTLSCertFile = getEnv("USER_SERVICE_TLS_CERT_FILE", "")
+	UserServiceTLSKeyFile = getEnv("USER_SERVICE_TLS_KEY_FILE", "")
+	UserServiceTLSServerName = getEnv("USER_SERVICE_TLS_SERVER_NAME", "")
+}
@@ -0,0 +1,24 @@
+package config
+
+import "strings"
+
+var (
+	// RateLimitsFile replaces the built-in rate limits with a JSON array of
+	// middleware.RateLimitRule.
+	RateLimitsFile string
+	// TrustedProxies are the addresses or CIDRs of the proxies in front of
+	// the gateway whose X-Forwarded-For header is believed when limiting
+	// per client IP. Empty trusts no proxy, so the client IP is the peer
+	// address.
+	TrustedProxies []string
+)
+
+func InitRateLimit() {
+	RateLimitsFile = getEnv("RATE_LIMITS_FILE", "")
+	TrustedProxies = nil
+	for _, proxy := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			TrustedProxies = append(TrustedProxies, proxy)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package config
+
+var (
+	// AvatarMaxBytes is the largest accepted avatar upload.
+	AvatarMaxBytes int
+	// MediaDir, when set, is served at /media. It must be the directory
+	// user-service stores uploads in with the local storage backend.
+	MediaDir string
+)
+
+func InitUpload() {
+	AvatarMaxBytes = getEnvAsInt("AVATAR_MAX_BYTES", 5<<20)
+	MediaDir = getEnv("MEDIA_DIR", "")
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"google.golang.org/grpc"
+)
+
+// avatarPath is where clients upload their avatar.
+const avatarPath = "/api/users/me/avatar"
+
+// avatarTypes are the accepted image types, as sniffed from the content.
+var avatarTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// RegisterAvatarUpload serves POST /api/users/me/avatar on mux. The image is
+// sent as a multipart form in the "file" field. grpc-gateway cannot decode
+// multipart bodies, so the upload is checked here and forwarded to
+// UploadAvatar, which validates and resizes it.
+func RegisterAvatarUpload(mux *runtime.ServeMux, client userProto.UserServiceClient, maxBytes int) error {
+	return mux.HandlePath(http.MethodPost, avatarPath, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, userProto.UserService_UploadAvatar_FullMethodName,
+			runtime.WithHTTPPathPattern(avatarPath))
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+			return
+		}
+
+		image, status, message := readAvatar(w, r, maxBytes)
+		if status != http.StatusOK {
+			writeError(w, status, message)
+			return
+		}
+
+		resp, err := client.UploadAvatar(ctx, &userProto.UploadAvatarRequest{Image: image},
+			grpc.MaxCallSendMsgSize(maxBytes+64<<10))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, resp)
+	})
+}
+
+// readAvatar returns the uploaded image, or the HTTP status and message to
+// reject the upload with.
+func readAvatar(w http.ResponseWriter, r *http.Request, maxBytes int) ([]byte, int, string) {
+	// Leave room for the multipart framing around the file.
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBytes)+64<<10)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, http.StatusRequestEntityTooLarge, "Image is too large"
+		}
+		return nil, http.StatusBadRequest, "Missing file field"
+	}
+	defer file.Close()
+
+	image, err := io.ReadAll(io.LimitReader(file, int64(maxBytes)+1))
+	if err != nil {
+		return nil, http.StatusBadRequest, "Failed to read file"
+	}
+	if len(image) > maxBytes {
+		return nil, http.StatusRequestEntityTooLarge, "Image is too large"
+	}
+	if !slices.Contains(avatarTypes, http.DetectContentType(image)) {
+		return nil, http.StatusUnsupportedMediaType, "Image must be JPEG, PNG, GIF or WebP"
+	}
+	return image, http.StatusOK, ""
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"code": status, "message": message})
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -24,7 +23,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.Register(context.Background(), &req)
+	resp, err := h.client.Register(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(500, gin.H{"code": 500, "message": err.Error()})
 		return
@@ -39,7 +38,7 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.Login(context.Background(), &req)
+	resp, err := h.client.Login(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(500, gin.H{"code": 500, "message": err.Error()})
 		return
@@ -49,7 +48,7 @@ func (h *UserHandler) Login(c *gin.Context) {
 
 func (h *UserHandler) GetUserInfo(c *gin.Context) {
 	userID, _ := c.Get("user_id")
-	resp, err := h.client.GetUserInfo(context.Background(), &userProto.GetUserInfoRequest{
+	resp, err := h.client.GetUserInfo(c.Request.Context(), &userProto.GetUserInfoRequest{
 		UserId: uint32(userID.(uint)),
 	})
 	if err != nil {
@@ -72,7 +71,7 @@ func (h *UserHandler) AddAddress(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.AddAddress(context.Background(), &userProto.AddAddressRequest{
+	resp, err := h.client.AddAddress(c.Request.Context(), &userProto.AddAddressRequest{
 		UserId:        uint32(userID.(uint)),
 		ReceiverName:  req.ReceiverName,
 		Phone:         req.Phone,
@@ -100,7 +99,7 @@ func (h *UserHandler) UpdateAddress(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.client.UpdateAddress(context.Background(), &userProto.UpdateAddressRequest{
+	resp, err := h.client.UpdateAddress(c.Request.Context(), &userProto.UpdateAddressRequest{
 		Id:            uint32(atoi(id)),
 		UserId:        uint32(userID.(uint)),
 		ReceiverName:  req.ReceiverName,
@@ -118,7 +117,7 @@ func (h *UserHandler) UpdateAddress(c *gin.Context) {
 func (h *UserHandler) DeleteAddress(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 	id := c.Param("id")
-	resp, err := h.client.DeleteAddress(context.Background(), &userProto.DeleteAddressRequest{
+	resp, err := h.client.DeleteAddress(c.Request.Context(), &userProto.DeleteAddressRequest{
 		Id:     uint32(atoi(id)),
 		UserId: uint32(userID.(uint)),
 	})
@@ -131,7 +130,7 @@ func (h *UserHandler) DeleteAddress(c *gin.Context) {
 
 func (h *UserHandler) GetAddresses(c *gin.Context) {
 	userID, _ := c.Get("user_id")
-	resp, err := h.client.GetAddresses(context.Background(), &userProto.GetAddressesRequest{
+	resp, err := h.client.GetAddresses(c.Request.Context(), &userProto.GetAddressesRequest{
 		UserId: uint32(userID.(uint)),
 	})
 	if err != nil {
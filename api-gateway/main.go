@@ -9,8 +9,11 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
+	"github.com/yinxi0607/YixiGroceryAPI/api-gateway/config"
 	_ "github.com/yinxi0607/YixiGroceryAPI/api-gateway/docs"
+	"github.com/yinxi0607/YixiGroceryAPI/api-gateway/handler"
 	"github.com/yinxi0607/YixiGroceryAPI/api-gateway/middleware"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/grpctls"
 	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -23,15 +26,31 @@ import (
 // @in header
 // @name Authorization
 func main() {
-	// Create Gin router
-	r := gin.Default()
-	r.Use(middleware.Auth())
+	// Connect to Redis for the token revocation list
+	config.InitRedis()
+	config.InitGRPC()
+	config.InitUpload()
+	config.InitRateLimit()
 
-	// Create gRPC-Gateway mux
-	gwMux := runtime.NewServeMux()
+	rateLimits, err := middleware.LoadRateLimits(config.RateLimitsFile)
+	if err != nil {
+		log.Fatalf("Failed to load rate limits: %v", err)
+	}
+
+	creds := insecure.NewCredentials()
+	if config.UserServiceTLSCAFile != "" {
+		creds, err = grpctls.ClientCredentials(config.UserServiceTLSCAFile, config.UserServiceTLSCertFile,
+			config.UserServiceTLSKeyFile, config.UserServiceTLSServerName)
+		if err != nil {
+			log.Fatalf("Failed to load TLS credentials: %v", err)
+		}
+	}
 
 	// gRPC connection to user-service
-	conn, err := grpc.NewClient("yinxi-user-service:8081", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient("yinxi-user-service:8081",
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(middleware.Authorize(), middleware.ForwardIdentity()),
+	)
 	if err != nil {
 		log.Fatalf("Failed to connect to user-service: %v", err)
 	}
@@ -42,13 +61,40 @@ func main() {
 		}
 	}(conn)
 
+	userClient := userProto.NewUserServiceClient(conn)
+
+	// Create Gin router
+	r := gin.Default()
+	// Without trusted proxies the client IP is the peer address; any
+	// X-Forwarded-For would let clients pick their rate limit bucket.
+	if err := r.SetTrustedProxies(config.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
+	limiter := middleware.NewRateLimiter(config.RedisClient, rateLimits)
+	r.Use(middleware.ClientIP())
+	r.Use(limiter.Anonymous())
+	r.Use(middleware.Auth(middleware.NewKeySet(userClient)))
+	r.Use(limiter.Authenticated())
+
+	// Create gRPC-Gateway mux
+	gwMux := runtime.NewServeMux()
+
 	// Register UserService handler
 	if err := userProto.RegisterUserServiceHandler(context.Background(), gwMux, conn); err != nil {
 		log.Fatalf("Failed to register user service handler: %v", err)
 	}
+	if err := handler.RegisterAvatarUpload(gwMux, userClient, config.AvatarMaxBytes); err != nil {
+		log.Fatalf("Failed to register avatar upload: %v", err)
+	}
 
 	// Mount gRPC-Gateway to Gin
 	r.Any("/api/*any", gin.WrapH(gwMux))
+	r.GET("/.well-known/jwks.json", gin.WrapH(gwMux))
+
+	// Uploads of user-service's local storage backend
+	if config.MediaDir != "" {
+		r.Static("/media", config.MediaDir)
+	}
 
 	// Swagger documentation route
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -1,14 +1,49 @@
 package middleware
 
 import (
-	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"errors"
 	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yinxi0607/YixiGroceryAPI/api-gateway/config"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/accesstoken"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
 )
 
-func Auth() gin.HandlerFunc {
+// publicPaths can be called without an access token.
+var publicPaths = map[string]bool{
+	"/api/auth/register":               true,
+	"/api/auth/login":                  true,
+	"/api/auth/refresh":                true,
+	"/api/auth/code":                   true,
+	"/api/auth/login/code":             true,
+	"/api/auth/login/totp":             true,
+	"/api/auth/password/reset":         true,
+	"/api/auth/password/reset/confirm": true,
+	"/.well-known/jwks.json":           true,
+}
+
+// publicPrefixes are path prefixes that can be called without an access
+// token.
+var publicPrefixes = []string{"/media/"}
+
+func isPublic(path string) bool {
+	if publicPaths[path] {
+		return true
+	}
+	for _, prefix := range publicPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Auth verifies the bearer access token against the key set published by
+// user-service and the Redis revocation list.
+func Auth(keys *KeySet) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if c.Request.URL.Path == "/api/auth/register" || c.Request.URL.Path == "/api/auth/login" {
+		if isPublic(c.Request.URL.Path) {
 			c.Next()
 			return
 		}
@@ -20,32 +55,27 @@ func Auth() gin.HandlerFunc {
 			return
 		}
 
-		if len(tokenStr) > 7 && strings.HasPrefix(tokenStr, "Bearer ") {
-			tokenStr = tokenStr[7:]
-		}
-
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte("your_jwt_secret"), nil
-		})
-		if err != nil || !token.Valid {
+		id, err := accesstoken.Verify(c.Request.Context(), config.RedisClient, accesstoken.FromHeader(tokenStr), keys.Keyfunc)
+		switch {
+		case errors.Is(err, accesstoken.ErrInvalid):
 			c.JSON(401, gin.H{"code": 401, "message": "Invalid token"})
 			c.Abort()
 			return
-		}
-
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.JSON(401, gin.H{"code": 401, "message": "Invalid claims"})
+		case errors.Is(err, accesstoken.ErrRevoked):
+			c.JSON(401, gin.H{"code": 401, "message": "Token has been revoked"})
 			c.Abort()
 			return
-		}
-		userID, ok := claims["user_id"].(float64)
-		if !ok {
-			c.JSON(401, gin.H{"code": 401, "message": "Invalid user_id"})
+		case err != nil:
+			c.JSON(503, gin.H{"code": 503, "message": "Unable to verify token"})
 			c.Abort()
 			return
 		}
-		c.Set("user_id", uint(userID))
+		c.Set("user_id", uint(id.UserID))
+		c.Set("role", id.Role)
+
+		// Make the verified identity visible to the gRPC client interceptor,
+		// which forwards it to the backend services as metadata.
+		c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), id))
 		c.Next()
 	}
 }
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/authz"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Authorize returns a gRPC client interceptor that enforces the permissions
+// declared in package authz before a call leaves the gateway. grpc-gateway
+// only resolves the gRPC method inside the mux, so this is the first point
+// where the method is known.
+func Authorize() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		id, ok := identity.FromContext(ctx)
+		var role authz.Role
+		if ok {
+			role = authz.Role(id.Role)
+		}
+		switch authz.Check(method, ok, role) {
+		case authz.Unauthenticated:
+			return status.Error(codes.Unauthenticated, "authentication required")
+		case authz.Denied:
+			return status.Errorf(codes.PermissionDenied, "permission denied for %s", method)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientIP makes the client IP resolved by gin, which only believes
+// X-Forwarded-For from trusted proxies, visible to ForwardIdentity. It must
+// run before any handler that calls a backend service.
+func ClientIP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(identity.NewClientIPContext(c.Request.Context(), c.ClientIP()))
+		c.Next()
+	}
+}
+
+// ForwardIdentity returns a gRPC client interceptor that replaces any
+// identity metadata on the outgoing call with the identity verified by Auth.
+// Identity headers supplied by the HTTP client (e.g. Grpc-Metadata-X-User-Id)
+// are always dropped. The client IP set by ClientIP is forwarded as well.
+func ForwardIdentity() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withIdentityMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func withIdentityMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	identity.Strip(md)
+	if id, ok := identity.FromContext(ctx); ok {
+		md = metadata.Join(md, id.ToMetadata())
+	}
+	if ip, ok := identity.ClientIPFromContext(ctx); ok {
+		md.Set(identity.MetadataClientIP, ip)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+)
+
+const (
+	// jwksTTL is how long a fetched key set is trusted before it is refreshed.
+	jwksTTL = 10 * time.Minute
+	// jwksMinRefresh limits refetches triggered by unknown key IDs, so that
+	// tokens with made-up kids cannot hammer user-service.
+	jwksMinRefresh = 30 * time.Second
+)
+
+// KeySet caches the JWT verification keys published by user-service. A
+// token signed with a kid that is not cached triggers a refetch, so newly
+// rolled out keys are picked up without restarting the gateway.
+type KeySet struct {
+	client userProto.UserServiceClient
+
+	mu        sync.Mutex
+	keys      map[string]jwkKey
+	fetchedAt time.Time
+}
+
+type jwkKey struct {
+	alg string
+	key interface{}
+}
+
+func NewKeySet(client userProto.UserServiceClient) *KeySet {
+	return &KeySet{client: client}
+}
+
+// Keyfunc resolves the public key for token by its kid header.
+func (s *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, err := s.lookup(context.Background(), kid)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != key.alg {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+	return key.key, nil
+}
+
+func (s *KeySet) lookup(ctx context.Context, kid string) (jwkKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[kid]
+	age := time.Since(s.fetchedAt)
+	if (ok && age < jwksTTL) || (!ok && s.keys != nil && age < jwksMinRefresh) {
+		if !ok {
+			return jwkKey{}, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		// Keep serving the cached keys if user-service is briefly unreachable.
+		if ok {
+			return key, nil
+		}
+		return jwkKey{}, err
+	}
+	if key, ok = s.keys[kid]; !ok {
+		return jwkKey{}, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+func (s *KeySet) refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := s.client.GetJWKS(ctx, &userProto.GetJWKSRequest{})
+	if err != nil {
+		return fmt.Errorf("fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]jwkKey, len(resp.Keys))
+	for _, jwk := range resp.Keys {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	s.keys, s.fetchedAt = keys, time.Now()
+	return nil
+}
+
+func parseJWK(jwk *userProto.JWK) (jwkKey, error) {
+	switch jwk.Kty {
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return jwkKey{}, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return jwkKey{}, fmt.Errorf("invalid Ed25519 key")
+		}
+		return jwkKey{alg: jwt.SigningMethodEdDSA.Alg(), key: ed25519.PublicKey(x)}, nil
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return jwkKey{}, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return jwkKey{}, err
+		}
+		return jwkKey{alg: jwt.SigningMethodRS256.Alg(), key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+	default:
+		return jwkKey{}, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
@@ -0,0 +1,318 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// Subjects a rate limit is counted per.
+const (
+	LimitByIP = "ip"
+	// LimitByUser counts per authenticated user and falls back to the client
+	// IP for anonymous requests.
+	LimitByUser = "user"
+)
+
+// RateLimitRule limits the requests matching Methods and Path to Limit per
+// Window for each subject. Bursts of up to Limit requests are allowed, and
+// the allowance refills evenly over Window.
+type RateLimitRule struct {
+	// Name identifies the rule in Redis keys, so renaming it resets its
+	// counters.
+	Name string `json:"name"`
+	// Methods restricts the rule to these HTTP methods; empty matches all.
+	Methods []string `json:"methods"`
+	// Path is an exact path or, ending in "*", a path prefix.
+	Path   string `json:"path"`
+	By     string `json:"by"`
+	Limit  int    `json:"limit"`
+	Window string `json:"window"`
+
+	window time.Duration
+}
+
+// DefaultRateLimits protect the anonymous auth endpoints against password
+// guessing and SMS flooding, and cap the overall request rate per client
+// IP, which includes requests with forged tokens, and per user.
+var DefaultRateLimits = []RateLimitRule{
+	{Name: "login", Methods: []string{"POST"}, Path: "/api/auth/login*", By: LimitByIP, Limit: 10, Window: "1m"},
+	{Name: "register", Methods: []string{"POST"}, Path: "/api/auth/register", By: LimitByIP, Limit: 5, Window: "10m"},
+	{Name: "code", Methods: []string{"POST"}, Path: "/api/auth/code", By: LimitByIP, Limit: 5, Window: "10m"},
+	{Name: "password-reset", Methods: []string{"POST"}, Path: "/api/auth/password/reset*", By: LimitByIP, Limit: 5, Window: "10m"},
+	{Name: "deletion-code", Methods: []string{"POST"}, Path: "/api/users/me/delete/code", By: LimitByUser, Limit: 5, Window: "10m"},
+	{Name: "api-ip", Path: "/api/*", By: LimitByIP, Limit: 1200, Window: "1m"},
+	{Name: "api", Path: "/api/*", By: LimitByUser, Limit: 300, Window: "1m"},
+}
+
+// LoadRateLimits reads the rules from a JSON array in file, or returns
+// DefaultRateLimits if file is empty.
+func LoadRateLimits(file string) ([]RateLimitRule, error) {
+	rules := DefaultRateLimits
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		rules = nil
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+	}
+
+	loaded := make([]RateLimitRule, len(rules))
+	names := map[string]bool{}
+	for i, rule := range rules {
+		window, err := time.ParseDuration(rule.Window)
+		switch {
+		case rule.Name == "" || names[rule.Name]:
+			return nil, fmt.Errorf("rate limit %d: name must be unique and not empty", i)
+		case rule.Path == "":
+			return nil, fmt.Errorf("rate limit %s: path is required", rule.Name)
+		case rule.By != LimitByIP && rule.By != LimitByUser:
+			return nil, fmt.Errorf("rate limit %s: by must be %q or %q", rule.Name, LimitByIP, LimitByUser)
+		case rule.Limit <= 0:
+			return nil, fmt.Errorf("rate limit %s: limit must be positive", rule.Name)
+		case err != nil || window < time.Second:
+			return nil, fmt.Errorf("rate limit %s: window must be a duration of at least 1s", rule.Name)
+		}
+		names[rule.Name] = true
+		rule.window = window
+		methods := make([]string, len(rule.Methods))
+		for j, method := range rule.Methods {
+			methods[j] = strings.ToUpper(method)
+		}
+		rule.Methods = methods
+		loaded[i] = rule
+	}
+	return loaded, nil
+}
+
+func (r *RateLimitRule) matches(method, path string) bool {
+	if len(r.Methods) > 0 && !contains(r.Methods, method) {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	return path == r.Path
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimitScript implements GCRA, a token bucket that stores only the
+// theoretical arrival time of the next request in microseconds, for every
+// key in KEYS at once. ARGV holds the time one request adds and the window
+// of each key in turn. A token is taken from every bucket only if all of
+// them allow the request, so a request rejected by one rule does not use up
+// the others. It returns, per key, whether the request is allowed, the
+// remaining requests, the microseconds until the next request is allowed
+// and until the bucket is full again. Redis time is used so that all
+// gateway replicas share one clock, which needs Redis 5 or later to
+// replicate the writes.
+var rateLimitScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local results = {}
+local new_tats = {}
+local allowed = true
+for i, key in ipairs(KEYS) do
+  local interval = tonumber(ARGV[2 * i - 1])
+  local window = tonumber(ARGV[2 * i])
+  local tat = tonumber(redis.call('GET', key) or now)
+  if tat < now then
+    tat = now
+  end
+  local new_tat = tat + interval
+  local allow_at = new_tat - window
+  if now < allow_at then
+    allowed = false
+    for _, v in ipairs({0, 0, allow_at - now, tat - now}) do
+      table.insert(results, v)
+    end
+  else
+    new_tats[i] = new_tat
+    for _, v in ipairs({1, math.floor((now - allow_at) / interval), 0, new_tat - now}) do
+      table.insert(results, v)
+    end
+  end
+end
+if allowed then
+  for i, key in ipairs(KEYS) do
+    redis.call('SET', key, new_tats[i], 'PX', math.ceil((new_tats[i] - now) / 1000))
+  end
+end
+return results
+`)
+
+type rateLimitResult struct {
+	allowed    bool
+	remaining  int64
+	retryAfter time.Duration
+	reset      time.Duration
+}
+
+// takeTokens takes a token for each key from the bucket of the rule at the
+// same index, or from none of them if one is exhausted.
+func takeTokens(ctx context.Context, rdb *redis.Client, keys []string, rules []*RateLimitRule) ([]*rateLimitResult, error) {
+	args := make([]interface{}, 0, 2*len(rules))
+	for _, rule := range rules {
+		args = append(args, rule.window.Microseconds()/int64(rule.Limit), rule.window.Microseconds())
+	}
+	res, err := rateLimitScript.Run(ctx, rdb, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) != 4*len(keys) {
+		return nil, fmt.Errorf("unexpected rate limit result %v", res)
+	}
+	results := make([]*rateLimitResult, len(keys))
+	for i := range results {
+		r := res[4*i:]
+		results[i] = &rateLimitResult{
+			allowed:    r[0] == 1,
+			remaining:  r[1],
+			retryAfter: time.Duration(r[2]) * time.Microsecond,
+			reset:      time.Duration(r[3]) * time.Microsecond,
+		}
+	}
+	return results, nil
+}
+
+// RateLimiter enforces rules, counted in Redis so that all gateway replicas
+// share the limits. It works in two phases around Auth: Anonymous counts
+// every per-IP limit before the token is checked, so requests Auth rejects
+// are counted too, and Authenticated counts the per-user limits once the
+// user is known. Every matching rule is applied, and a request is only
+// counted if all rules of the phase allow it; the RateLimit-* headers
+// describe the one with the fewest requests left. When Redis is unavailable
+// requests are let through rather than failing the whole API.
+type RateLimiter struct {
+	rdb   *redis.Client
+	rules []RateLimitRule
+}
+
+func NewRateLimiter(rdb *redis.Client, rules []RateLimitRule) *RateLimiter {
+	return &RateLimiter{rdb: rdb, rules: rules}
+}
+
+// Anonymous must run before Auth. It counts the per-IP rules, and the
+// per-user rules of requests without an access token by client IP.
+func (l *RateLimiter) Anonymous() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		anonymous := c.GetHeader("Authorization") == ""
+		l.limit(c, func(rule *RateLimitRule) string {
+			if rule.By == LimitByIP || anonymous {
+				return "ip:" + c.ClientIP()
+			}
+			return ""
+		})
+	}
+}
+
+// Authenticated must run after Auth. It counts the per-user rules of
+// requests that carried an access token, by user, or by client IP on public
+// paths where Auth does not check the token.
+func (l *RateLimiter) Authenticated() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		l.limit(c, func(rule *RateLimitRule) string {
+			if rule.By != LimitByUser {
+				return ""
+			}
+			if userID, ok := c.Get("user_id"); ok {
+				return fmt.Sprintf("user:%v", userID)
+			}
+			return "ip:" + c.ClientIP()
+		})
+	}
+}
+
+// rateLimitState is the tightest limit seen by the phases so far.
+type rateLimitState struct {
+	rule   *RateLimitRule
+	result *rateLimitResult
+}
+
+const rateLimitStateKey = "rate_limit"
+
+// limit takes a token from every matching rule that subject assigns a
+// subject to, and rejects the request once one of them is exhausted. The
+// rules of one phase are checked together, so a rejected request does not
+// count against the other rules of the phase.
+func (l *RateLimiter) limit(c *gin.Context, subject func(*RateLimitRule) string) {
+	method, path := c.Request.Method, c.Request.URL.Path
+
+	var tightest rateLimitState
+	if v, ok := c.Get(rateLimitStateKey); ok {
+		tightest = v.(rateLimitState)
+	}
+	var keys []string
+	var rules []*RateLimitRule
+	for i := range l.rules {
+		rule := &l.rules[i]
+		if !rule.matches(method, path) {
+			continue
+		}
+		if s := subject(rule); s != "" {
+			keys = append(keys, "rate_limit:"+rule.Name+":"+s)
+			rules = append(rules, rule)
+		}
+	}
+	if len(keys) > 0 {
+		results, err := takeTokens(c.Request.Context(), l.rdb, keys, rules)
+		if err != nil {
+			log.Printf("Rate limits not checked: %v", err)
+		}
+		for i, res := range results {
+			if tightest.result == nil || !res.allowed || res.remaining < tightest.result.remaining {
+				tightest = rateLimitState{rule: rules[i], result: res}
+			}
+			if !res.allowed {
+				break
+			}
+		}
+	}
+	if tightest.result == nil {
+		c.Next()
+		return
+	}
+	c.Set(rateLimitStateKey, tightest)
+
+	h := c.Writer.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(tightest.rule.Limit))
+	h.Set("RateLimit-Remaining", strconv.FormatInt(tightest.result.remaining, 10))
+	h.Set("RateLimit-Reset", strconv.Itoa(seconds(tightest.result.reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", tightest.rule.Limit, seconds(tightest.rule.window)))
+	if !tightest.result.allowed {
+		h.Set("Retry-After", strconv.Itoa(seconds(tightest.result.retryAfter)))
+		c.JSON(429, gin.H{"code": 429, "message": "Too many requests"})
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// seconds rounds d up to whole seconds, as the headers take.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,279 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	mr.SetTime(time.Unix(1700000000, 0))
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return mr, rdb
+}
+
+func loadTestRules(t *testing.T, rules string) []RateLimitRule {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(file, []byte(rules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadRateLimits(file)
+	if err != nil {
+		t.Fatalf("LoadRateLimits: %v", err)
+	}
+	return loaded
+}
+
+func takeToken(ctx context.Context, rdb *redis.Client, key string, rule *RateLimitRule) (*rateLimitResult, error) {
+	results, err := takeTokens(ctx, rdb, []string{key}, []*RateLimitRule{rule})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func TestTakeTokenBurstAndEmission(t *testing.T) {
+	mr, rdb := newTestRedis(t)
+	rule := &loadTestRules(t, `[{"name": "r", "path": "/x", "by": "ip", "limit": 5, "window": "10s"}]`)[0]
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		res, err := takeToken(ctx, rdb, "k", rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.allowed || res.remaining != int64(4-i) {
+			t.Fatalf("request %d: allowed %v remaining %d, want true %d", i, res.allowed, res.remaining, 4-i)
+		}
+	}
+
+	res, err := takeToken(ctx, rdb, "k", rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.allowed || res.retryAfter != 2*time.Second || res.reset != 10*time.Second {
+		t.Fatalf("over limit: got %+v, want rejected with retry after 2s and reset 10s", res)
+	}
+
+	// One request is emitted every window/limit.
+	mr.SetTime(time.Unix(1700000002, 0))
+	if res, _ := takeToken(ctx, rdb, "k", rule); !res.allowed || res.remaining != 0 {
+		t.Fatalf("after 2s: got %+v, want one allowed request", res)
+	}
+	if res, _ := takeToken(ctx, rdb, "k", rule); res.allowed {
+		t.Fatal("after 2s: second request allowed")
+	}
+
+	// An idle bucket refills up to the burst, not beyond.
+	mr.SetTime(time.Unix(1700000100, 0))
+	if res, _ := takeToken(ctx, rdb, "k", rule); !res.allowed || res.remaining != 4 {
+		t.Fatalf("after idling: got %+v, want remaining 4", res)
+	}
+}
+
+func TestTakeTokensAllOrNothing(t *testing.T) {
+	mr, rdb := newTestRedis(t)
+	rules := loadTestRules(t, `[
+		{"name": "loose", "path": "/x", "by": "ip", "limit": 10, "window": "10s"},
+		{"name": "tight", "path": "/x", "by": "ip", "limit": 1, "window": "10s"}
+	]`)
+	keys := []string{"loose", "tight"}
+	ctx := context.Background()
+
+	for i, wantAllowed := range []bool{true, false, false} {
+		results, err := takeTokens(ctx, rdb, keys, []*RateLimitRule{&rules[0], &rules[1]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results[0].allowed != true || results[1].allowed != wantAllowed {
+			t.Fatalf("request %d: allowed %v %v, want true %v", i, results[0].allowed, results[1].allowed, wantAllowed)
+		}
+	}
+	// Only the first request took a token from the loose bucket.
+	if n := tatRequests(t, mr, "loose", time.Second); n != 1 {
+		t.Fatalf("loose bucket counted %d requests, want 1", n)
+	}
+}
+
+func TestLoadRateLimits(t *testing.T) {
+	defaults, err := LoadRateLimits("")
+	if err != nil || len(defaults) != len(DefaultRateLimits) {
+		t.Fatalf("defaults: %d rules, %v", len(defaults), err)
+	}
+
+	rules := loadTestRules(t, `[{"name": "r", "methods": ["post"], "path": "/a/*", "by": "user", "limit": 1, "window": "1m"}]`)
+	if rules[0].window != time.Minute || !rules[0].matches("POST", "/a/b") || rules[0].matches("GET", "/a/b") || rules[0].matches("POST", "/b") {
+		t.Fatalf("unexpected rule %+v", rules[0])
+	}
+
+	for name, content := range map[string]string{
+		"not json":       `{`,
+		"no name":        `[{"path": "/a", "by": "ip", "limit": 1, "window": "1m"}]`,
+		"duplicate name": `[{"name": "a", "path": "/a", "by": "ip", "limit": 1, "window": "1m"}, {"name": "a", "path": "/b", "by": "ip", "limit": 1, "window": "1m"}]`,
+		"no path":        `[{"name": "a", "by": "ip", "limit": 1, "window": "1m"}]`,
+		"bad subject":    `[{"name": "a", "path": "/a", "by": "session", "limit": 1, "window": "1m"}]`,
+		"zero limit":     `[{"name": "a", "path": "/a", "by": "ip", "limit": 0, "window": "1m"}]`,
+		"bad window":     `[{"name": "a", "path": "/a", "by": "ip", "limit": 1, "window": "soon"}]`,
+		"short window":   `[{"name": "a", "path": "/a", "by": "ip", "limit": 1, "window": "10ms"}]`,
+	} {
+		file := filepath.Join(t.TempDir(), "rules.json")
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadRateLimits(file); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+	if _, err := LoadRateLimits(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("missing file: no error")
+	}
+}
+
+// newTestRouter mimics the gateway: Auth accepts the token "good" as user 7
+// and rejects every other token.
+func newTestRouter(rdb *redis.Client, rules []RateLimitRule) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	limiter := NewRateLimiter(rdb, rules)
+	r := gin.New()
+	r.SetTrustedProxies(nil)
+	r.Use(limiter.Anonymous())
+	r.Use(func(c *gin.Context) {
+		switch c.GetHeader("Authorization") {
+		case "":
+		case "good":
+			c.Set("user_id", uint(7))
+		default:
+			c.AbortWithStatus(401)
+		}
+	})
+	r.Use(limiter.Authenticated())
+	r.Any("/*path", func(c *gin.Context) { c.Status(200) })
+	return r
+}
+
+func serve(r http.Handler, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/api/x", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Forwarded-For", "198.51.100."+token)
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitSubjects(t *testing.T) {
+	mr, rdb := newTestRedis(t)
+	r := newTestRouter(rdb, loadTestRules(t, `[
+		{"name": "ip", "path": "/api/*", "by": "ip", "limit": 100, "window": "1m"},
+		{"name": "user", "path": "/api/*", "by": "user", "limit": 2, "window": "1m"}
+	]`))
+
+	if w := serve(r, ""); w.Code != 200 {
+		t.Fatalf("anonymous: status %d", w.Code)
+	}
+	if w := serve(r, "good"); w.Code != 200 {
+		t.Fatalf("authenticated: status %d", w.Code)
+	}
+	if w := serve(r, "forged"); w.Code != 401 {
+		t.Fatalf("forged: status %d", w.Code)
+	}
+
+	want := []string{"rate_limit:ip:ip:192.0.2.1", "rate_limit:user:ip:192.0.2.1", "rate_limit:user:user:7"}
+	if keys := mr.Keys(); strings.Join(keys, " ") != strings.Join(want, " ") {
+		t.Fatalf("keys %v, want %v", keys, want)
+	}
+	// X-Forwarded-For of an untrusted peer does not change the bucket and
+	// forged tokens were counted per IP.
+	if n := tatRequests(t, mr, "rate_limit:ip:ip:192.0.2.1", 600*time.Millisecond); n != 3 {
+		t.Fatalf("per-IP bucket counted %d requests, want 3", n)
+	}
+
+	// The user has its own bucket, independent of other users behind the
+	// same IP.
+	serve(r, "good")
+	w := serve(r, "good")
+	if w.Code != 429 {
+		t.Fatalf("third request of user: status %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After %q, want 30", got)
+	}
+	if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+		t.Errorf("RateLimit-Limit %q, want 2", got)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("RateLimit-Remaining %q, want 0", got)
+	}
+	if got := w.Header().Get("RateLimit-Policy"); got != "2;w=60" {
+		t.Errorf("RateLimit-Policy %q, want 2;w=60", got)
+	}
+	if w := serve(r, ""); w.Code != 200 {
+		t.Fatalf("anonymous after user limit: status %d", w.Code)
+	}
+}
+
+// tatRequests converts the stored arrival time back into the number of
+// requests taken at the frozen test time.
+func tatRequests(t *testing.T, mr *miniredis.Miniredis, key string, interval time.Duration) int {
+	t.Helper()
+	v, err := mr.Get(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tat, err := time.ParseDuration(v + "us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int((tat - time.Duration(1700000000)*time.Second) / interval)
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	_, rdb := newTestRedis(t)
+	r := newTestRouter(rdb, loadTestRules(t, `[
+		{"name": "loose", "path": "/api/*", "by": "ip", "limit": 100, "window": "1m"},
+		{"name": "tight", "path": "/api/x", "by": "ip", "limit": 3, "window": "30s"}
+	]`))
+
+	w := serve(r, "")
+	if w.Code != 200 {
+		t.Fatalf("status %d", w.Code)
+	}
+	for header, want := range map[string]string{
+		"RateLimit-Limit":     "3",
+		"RateLimit-Remaining": "2",
+		"RateLimit-Reset":     "10",
+		"RateLimit-Policy":    "3;w=30",
+		"Retry-After":         "",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestRateLimitFailsOpen(t *testing.T) {
+	mr, rdb := newTestRedis(t)
+	r := newTestRouter(rdb, loadTestRules(t, `[{"name": "r", "path": "/api/*", "by": "ip", "limit": 1, "window": "1m"}]`))
+	mr.Close()
+
+	for i := 0; i < 3; i++ {
+		if w := serve(r, ""); w.Code != 200 || w.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("request %d without Redis: status %d, headers %v", i, w.Code, w.Header())
+		}
+	}
+}
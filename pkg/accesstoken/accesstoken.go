@@ -0,0 +1,95 @@
+// Package accesstoken verifies the JWT access tokens issued by user-service.
+// The api-gateway and user-service share it, so both accept exactly the same
+// tokens.
+package accesstoken
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/authz"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/revocation"
+)
+
+var (
+	ErrInvalid = errors.New("invalid access token")
+	ErrRevoked = errors.New("access token has been revoked")
+)
+
+// validMethods are the signing algorithms user-service can be configured with.
+var validMethods = []string{jwt.SigningMethodEdDSA.Alg(), jwt.SigningMethodRS256.Alg()}
+
+// FromHeader strips the optional "Bearer " prefix of an Authorization value.
+func FromHeader(value string) string {
+	if len(value) > 7 && strings.HasPrefix(value, "Bearer ") {
+		return value[7:]
+	}
+	return value
+}
+
+// Verify checks the signature of tokenStr with the keys of keyfunc and the
+// revocation list in rdb, and returns the identity the token carries. Errors
+// other than ErrInvalid and ErrRevoked mean the revocation list could not be
+// read.
+func Verify(ctx context.Context, rdb *redis.Client, tokenStr string, keyfunc jwt.Keyfunc) (*identity.Identity, error) {
+	token, err := jwt.Parse(tokenStr, keyfunc, jwt.WithValidMethods(validMethods))
+	if err != nil || !token.Valid {
+		return nil, ErrInvalid
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalid
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, ErrInvalid
+	}
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = string(authz.RoleCustomer)
+	}
+	jti, _ := claims["jti"].(string)
+	sid, _ := claims["sid"].(string)
+	iat, err := claims.GetIssuedAt()
+	if jti == "" || err != nil || iat == nil {
+		return nil, ErrInvalid
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, ErrInvalid
+	}
+
+	// iat carries milliseconds for the revocation check, which
+	// GetIssuedAt truncates to seconds.
+	issuedAt := iat.Time
+	if v, ok := claims["iat"].(float64); ok {
+		issuedAt = time.UnixMilli(int64(math.Round(v * 1000)))
+	}
+
+	revoked, err := revocation.IsRevoked(ctx, rdb, revocation.Token{
+		ID:        jti,
+		SessionID: sid,
+		UserID:    uint32(userID),
+		IssuedAt:  issuedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrRevoked
+	}
+
+	return &identity.Identity{
+		UserID:    uint32(userID),
+		TokenID:   jti,
+		ExpiresAt: exp.Time,
+		Role:      role,
+		SessionID: sid,
+	}, nil
+}
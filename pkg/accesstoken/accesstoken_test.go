@@ -0,0 +1,54 @@
+package accesstoken
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/revocation"
+)
+
+func TestVerifyAfterSignOut(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyfunc := func(*jwt.Token) (interface{}, error) { return public, nil }
+	sign := func(jti string, iat time.Time) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{
+			"user_id": 7,
+			"jti":     jti,
+			"iat":     float64(iat.UnixMilli()) / 1000,
+			"exp":     iat.Add(time.Hour).Unix(),
+		}).SignedString(private)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	ctx := context.Background()
+
+	old := sign("old", time.Now().Add(-time.Millisecond))
+	if err := revocation.RevokeUser(ctx, rdb, 7, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	fresh := sign("fresh", time.Now())
+
+	if _, err := Verify(ctx, rdb, old, keyfunc); !errors.Is(err, ErrRevoked) {
+		t.Errorf("token issued before the sign-out: got %v, want ErrRevoked", err)
+	}
+	// Most likely issued in the same second as the sign-out.
+	if id, err := Verify(ctx, rdb, fresh, keyfunc); err != nil || id.UserID != 7 {
+		t.Errorf("token issued after the sign-out: got %v, %v", id, err)
+	}
+}
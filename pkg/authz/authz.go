@@ -0,0 +1,151 @@
+// Package authz declares which permissions each gRPC method requires and
+// which permissions each role grants. The api-gateway and user-service both
+// enforce it, so the two can never disagree.
+package authz
+
+import userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleStaff    Role = "staff"
+	RoleAdmin    Role = "admin"
+	// RoleService is held by internal services calling without a user
+	// identity. It is never assigned to user accounts.
+	RoleService Role = "service"
+)
+
+type Permission string
+
+const (
+	// PermSelf covers managing one's own account, profile and addresses.
+	PermSelf         Permission = "self"
+	PermUsersRead    Permission = "users:read"
+	PermUsersManage  Permission = "users:manage"
+	PermPointsAdjust Permission = "points:adjust"
+	// PermRolesManage covers assigning roles, which can grant every other
+	// permission, so only admins hold it.
+	PermRolesManage Permission = "roles:manage"
+)
+
+// Rule is the access rule of one method. Public methods need no caller
+// identity at all; every other method needs all of Permissions.
+type Rule struct {
+	Public      bool
+	Permissions []Permission
+}
+
+var (
+	public = Rule{Public: true}
+	self   = Rule{Permissions: []Permission{PermSelf}}
+)
+
+// Methods maps every full gRPC method name to its rule. Methods missing from
+// the map are denied.
+var Methods = map[string]Rule{
+	userProto.UserService_Register_FullMethodName:             public,
+	userProto.UserService_Login_FullMethodName:                public,
+	userProto.UserService_SendLoginCode_FullMethodName:        public,
+	userProto.UserService_LoginWithCode_FullMethodName:        public,
+	userProto.UserService_LoginWithTOTP_FullMethodName:        public,
+	userProto.UserService_RefreshToken_FullMethodName:         public,
+	userProto.UserService_RequestPasswordReset_FullMethodName: public,
+	userProto.UserService_ConfirmPasswordReset_FullMethodName: public,
+	userProto.UserService_GetJWKS_FullMethodName:              public,
+
+	userProto.UserService_Logout_FullMethodName:                  self,
+	userProto.UserService_ChangePassword_FullMethodName:          self,
+	userProto.UserService_EnableTOTP_FullMethodName:              self,
+	userProto.UserService_VerifyTOTP_FullMethodName:              self,
+	userProto.UserService_DisableTOTP_FullMethodName:             self,
+	userProto.UserService_GetUserInfo_FullMethodName:             self,
+	userProto.UserService_UpdateUserInfo_FullMethodName:          self,
+	userProto.UserService_UploadAvatar_FullMethodName:            self,
+	userProto.UserService_ListSessions_FullMethodName:            self,
+	userProto.UserService_RevokeSession_FullMethodName:           self,
+	userProto.UserService_DeleteMyAccount_FullMethodName:         self,
+	userProto.UserService_SendAccountDeletionCode_FullMethodName: self,
+	userProto.UserService_CancelAccountDeletion_FullMethodName:   self,
+	userProto.UserService_ExportMyData_FullMethodName:            self,
+	userProto.UserService_AddAddress_FullMethodName:              self,
+	userProto.UserService_UpdateAddress_FullMethodName:           self,
+	userProto.UserService_GetAddress_FullMethodName:              self,
+	userProto.UserService_DeleteAddress_FullMethodName:           self,
+	userProto.UserService_SetDefaultAddress_FullMethodName:       self,
+	userProto.UserService_CheckDeliverable_FullMethodName:        self,
+	userProto.UserService_Autocomplete_FullMethodName:            self,
+	userProto.UserService_ReverseGeocode_FullMethodName:          self,
+	userProto.UserService_GetAddresses_FullMethodName:            self,
+
+	userProto.UserService_GetLoginLockout_FullMethodName:   {Permissions: []Permission{PermUsersRead}},
+	userProto.UserService_ClearLoginLockout_FullMethodName: {Permissions: []Permission{PermUsersManage}},
+	userProto.UserService_ListUsers_FullMethodName:         {Permissions: []Permission{PermUsersRead}},
+	userProto.UserService_GetUser_FullMethodName:           {Permissions: []Permission{PermUsersRead}},
+	userProto.UserService_DisableUser_FullMethodName:       {Permissions: []Permission{PermUsersManage}},
+	userProto.UserService_SetUserRole_FullMethodName:       {Permissions: []Permission{PermRolesManage}},
+	userProto.UserService_AdjustPoints_FullMethodName:      {Permissions: []Permission{PermPointsAdjust}},
+	userProto.UserService_EarnPoints_FullMethodName:        {Permissions: []Permission{PermPointsAdjust}},
+	userProto.UserService_RedeemPoints_FullMethodName:      self,
+	userProto.UserService_ListPointsHistory_FullMethodName: self,
+	userProto.UserService_GetExpiringPoints_FullMethodName: self,
+	userProto.UserService_GetMembership_FullMethodName:     self,
+	userProto.UserService_GetReferrals_FullMethodName:      self,
+	userProto.UserService_CompleteReferral_FullMethodName:  {Permissions: []Permission{PermPointsAdjust}},
+}
+
+// Roles maps each role to the permissions it grants.
+var Roles = map[Role][]Permission{
+	RoleCustomer: {PermSelf},
+	RoleStaff:    {PermSelf, PermUsersRead, PermPointsAdjust},
+	RoleAdmin:    {PermSelf, PermUsersRead, PermUsersManage, PermPointsAdjust, PermRolesManage},
+	RoleService:  {PermUsersRead, PermPointsAdjust},
+}
+
+// ValidRole reports whether role is one of the roles a user account can hold.
+func ValidRole(role string) bool {
+	_, ok := Roles[Role(role)]
+	return ok && Role(role) != RoleService
+}
+
+// Decision is the outcome of Check.
+type Decision int
+
+const (
+	Allow Decision = iota
+	// Unauthenticated means the method needs a caller but there is none.
+	Unauthenticated
+	// Denied means the method is unknown or the role lacks a permission.
+	Denied
+)
+
+// Check decides whether a caller may invoke method. authenticated reports
+// whether the caller presented a valid identity, role is its role.
+func Check(method string, authenticated bool, role Role) Decision {
+	rule, ok := Methods[method]
+	if !ok {
+		return Denied
+	}
+	if rule.Public {
+		return Allow
+	}
+	if !authenticated {
+		return Unauthenticated
+	}
+	for _, need := range rule.Permissions {
+		if !HasPermission(role, need) {
+			return Denied
+		}
+	}
+	return Allow
+}
+
+// HasPermission reports whether role grants perm.
+func HasPermission(role Role, perm Permission) bool {
+	for _, p := range Roles[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
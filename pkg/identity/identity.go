@@ -0,0 +1,114 @@
+// Package identity carries the authenticated caller between the api-gateway
+// and the backend services as gRPC metadata.
+package identity
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys used to forward the verified claims. Clients must never be
+// able to set these themselves; the gateway strips them from incoming
+// requests before adding its own values.
+const (
+	MetadataUserID    = "x-user-id"
+	MetadataTokenID   = "x-token-id"
+	MetadataExpiresAt = "x-token-exp"
+	MetadataRole      = "x-user-role"
+	MetadataSessionID = "x-session-id"
+	// MetadataClientIP is the end user's address as resolved by the gateway
+	// from the peer address and the trusted proxies. It is forwarded for
+	// anonymous requests too.
+	MetadataClientIP = "x-client-ip"
+)
+
+// Identity is the verified caller of a request.
+type Identity struct {
+	UserID uint32
+	// TokenID is the jti of the access token the caller presented.
+	TokenID string
+	// ExpiresAt is when that access token expires.
+	ExpiresAt time.Time
+	// Role is the caller's role, see package authz.
+	Role string
+	// SessionID is the login session the access token belongs to.
+	SessionID string
+}
+
+type contextKey struct{}
+
+type clientIPKey struct{}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the identity stored in ctx, if any.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(contextKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// NewClientIPContext returns a copy of ctx carrying the client IP.
+func NewClientIPContext(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the client IP stored in ctx, if any.
+func ClientIPFromContext(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(clientIPKey{}).(string)
+	return ip, ok && ip != ""
+}
+
+// Strip removes every identity key from md.
+func Strip(md metadata.MD) {
+	md.Delete(MetadataUserID)
+	md.Delete(MetadataTokenID)
+	md.Delete(MetadataExpiresAt)
+	md.Delete(MetadataRole)
+	md.Delete(MetadataSessionID)
+	md.Delete(MetadataClientIP)
+}
+
+// ToMetadata encodes id as gRPC metadata.
+func (id *Identity) ToMetadata() metadata.MD {
+	return metadata.Pairs(
+		MetadataUserID, strconv.FormatUint(uint64(id.UserID), 10),
+		MetadataTokenID, id.TokenID,
+		MetadataExpiresAt, strconv.FormatInt(id.ExpiresAt.Unix(), 10),
+		MetadataRole, id.Role,
+		MetadataSessionID, id.SessionID,
+	)
+}
+
+// FromMetadata decodes an identity previously written by ToMetadata.
+func FromMetadata(md metadata.MD) (*Identity, bool) {
+	vals := md.Get(MetadataUserID)
+	if len(vals) != 1 {
+		return nil, false
+	}
+	userID, err := strconv.ParseUint(vals[0], 10, 32)
+	if err != nil || userID == 0 {
+		return nil, false
+	}
+	id := &Identity{UserID: uint32(userID)}
+	if vals := md.Get(MetadataTokenID); len(vals) == 1 {
+		id.TokenID = vals[0]
+	}
+	if vals := md.Get(MetadataRole); len(vals) == 1 {
+		id.Role = vals[0]
+	}
+	if vals := md.Get(MetadataSessionID); len(vals) == 1 {
+		id.SessionID = vals[0]
+	}
+	if vals := md.Get(MetadataExpiresAt); len(vals) == 1 {
+		if exp, err := strconv.ParseInt(vals[0], 10, 64); err == nil {
+			id.ExpiresAt = time.Unix(exp, 0)
+		}
+	}
+	return id, true
+}
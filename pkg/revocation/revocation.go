@@ -0,0 +1,84 @@
+// Package revocation implements the Redis-backed access token revocation
+// list shared by user-service, which writes it, and the api-gateway, which
+// checks it on every authenticated request.
+package revocation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func tokenKey(jti string) string {
+	return "revoked_token:" + jti
+}
+
+func userKey(userID uint32) string {
+	return "revoked_before:" + strconv.FormatUint(uint64(userID), 10)
+}
+
+func sessionKey(sessionID string) string {
+	return "revoked_session:" + sessionID
+}
+
+// Token identifies an access token to IsRevoked.
+type Token struct {
+	ID        string
+	SessionID string
+	UserID    uint32
+	IssuedAt  time.Time
+}
+
+// RevokeToken blacklists a single access token until it would have expired
+// anyway.
+func RevokeToken(ctx context.Context, rdb *redis.Client, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return rdb.Set(ctx, tokenKey(jti), 1, ttl).Err()
+}
+
+// RevokeUser invalidates every access token issued to userID before now. The
+// marker holds Unix milliseconds and only has to outlive the longest-lived
+// access token.
+func RevokeUser(ctx context.Context, rdb *redis.Client, userID uint32, maxTokenTTL time.Duration) error {
+	return rdb.Set(ctx, userKey(userID), time.Now().UnixMilli(), maxTokenTTL).Err()
+}
+
+// RevokeSession invalidates every access token of a login session. The
+// marker only has to outlive the longest-lived access token.
+func RevokeSession(ctx context.Context, rdb *redis.Client, sessionID string, maxTokenTTL time.Duration) error {
+	return rdb.Set(ctx, sessionKey(sessionID), 1, maxTokenTTL).Err()
+}
+
+// IsRevoked reports whether an access token has been revoked individually,
+// with its session or by a user-wide sign-out.
+func IsRevoked(ctx context.Context, rdb *redis.Client, t Token) (bool, error) {
+	vals, err := rdb.MGet(ctx, tokenKey(t.ID), userKey(t.UserID), sessionKey(t.SessionID)).Result()
+	if err != nil {
+		return false, err
+	}
+	if vals[0] != nil || (t.SessionID != "" && vals[2] != nil) {
+		return true, nil
+	}
+	if vals[1] != nil {
+		s, ok := vals[1].(string)
+		if !ok {
+			return false, errors.New("revocation: malformed user marker")
+		}
+		before, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return false, err
+		}
+		// Tokens issued right after the sign-out, e.g. by the login that
+		// follows a password change, stay valid.
+		if t.IssuedAt.UnixMilli() < before {
+			return true, nil
+		}
+	}
+	return false, nil
+}
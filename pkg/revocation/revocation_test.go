@@ -0,0 +1,42 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRevokeUser(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	ctx := context.Background()
+
+	before := time.Now().Add(-time.Millisecond)
+	if err := RevokeUser(ctx, rdb, 7, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	after := time.Now()
+
+	for _, tc := range []struct {
+		name string
+		t    Token
+		want bool
+	}{
+		{"issued before", Token{ID: "a", UserID: 7, IssuedAt: before}, true},
+		{"issued right after", Token{ID: "b", UserID: 7, IssuedAt: after}, false},
+		{"other user", Token{ID: "c", UserID: 8, IssuedAt: before}, false},
+	} {
+		revoked, err := IsRevoked(ctx, rdb, tc.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if revoked != tc.want {
+			t.Errorf("%s: revoked %v, want %v", tc.name, revoked, tc.want)
+		}
+	}
+}
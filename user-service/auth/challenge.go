@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+)
+
+// When two-factor authentication is on, the first login step only yields a
+// challenge token, redeemed together with a TOTP or recovery code:
+//
+//	login_challenge:<digest>   hash {user_id, device_name, attempts}
+//	totp_used:<id>:<step>      marks a TOTP time step as spent
+var ErrChallengeInvalid = errors.New("invalid or expired login challenge")
+
+// maxChallengeAttempts is how many wrong codes discard a challenge.
+const maxChallengeAttempts = 5
+
+func challengeKey(token string) string { return "login_challenge:" + digestToken(token) }
+
+var challengeScript = redis.NewScript(`
+local fields = redis.call('HMGET', KEYS[1], 'user_id', 'device_name')
+if not fields[1] then
+  return false
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if attempts > tonumber(ARGV[1]) then
+  redis.call('DEL', KEYS[1])
+  return false
+end
+return {fields[1], fields[2] or ''}
+`)
+
+// IssueChallenge starts the second login step for userID on the device
+// deviceName.
+func IssueChallenge(ctx context.Context, userID uint, deviceName string) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	pipe := config.RedisClient.TxPipeline()
+	pipe.HSet(ctx, challengeKey(token), "user_id", userID, "device_name", deviceName, "attempts", 0)
+	pipe.Expire(ctx, challengeKey(token), config.TwoFactorChallengeTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ChallengeUser counts an attempt against the challenge and returns the user
+// and device name it was issued to.
+func ChallengeUser(ctx context.Context, token string) (uint, string, error) {
+	vals, err := challengeScript.Run(ctx, config.RedisClient, []string{challengeKey(token)}, maxChallengeAttempts).StringSlice()
+	if errors.Is(err, redis.Nil) {
+		return 0, "", ErrChallengeInvalid
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	userID, err := strconv.ParseUint(vals[0], 10, 32)
+	return uint(userID), vals[1], err
+}
+
+// CompleteChallenge discards a challenge once it has been passed.
+func CompleteChallenge(ctx context.Context, token string) error {
+	return config.RedisClient.Del(ctx, challengeKey(token)).Err()
+}
+
+// MarkTOTPStepUsed records that the TOTP code of step was used by userID. It
+// returns false if the code was already used, so it cannot be replayed.
+func MarkTOTPStepUsed(ctx context.Context, userID uint, step int64) (bool, error) {
+	key := "totp_used:" + strconv.FormatUint(uint64(userID), 10) + ":" + strconv.FormatInt(step, 10)
+	// A step stays acceptable for at most three periods because of clock skew.
+	return config.RedisClient.SetNX(ctx, key, 1, 3*30*time.Second).Result()
+}
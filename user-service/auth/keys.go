@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+)
+
+// signingKey is one key of the keyring. Keys without a private part are only
+// used to verify tokens signed before they were retired.
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// JWK is the public half of a signing key in RFC 7517 form.
+type JWK struct {
+	Kty string
+	Kid string
+	Alg string
+	Crv string
+	X   string
+	N   string
+	E   string
+}
+
+var (
+	keysMu     sync.RWMutex
+	keys       map[string]*signingKey
+	signingKID string
+)
+
+// InitKeys loads the JWT keyring from config.JWTKeysDir and reloads it every
+// config.JWTKeysReloadInterval, so keys can be added, promoted and retired
+// without restarting the service.
+func InitKeys() {
+	if err := reloadKeys(); err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	if config.JWTKeysReloadInterval <= 0 {
+		return
+	}
+	go func() {
+		for range time.Tick(config.JWTKeysReloadInterval) {
+			if err := reloadKeys(); err != nil {
+				log.Printf("Failed to reload JWT keys, keeping previous keyring: %v", err)
+			}
+		}
+	}()
+}
+
+// reloadKeys reads every *.pem file in the key directory. The file name
+// without extension is the key ID. The signing key is config.JWTSigningKID
+// or, if unset, the last private key in lexical order.
+func reloadKeys() error {
+	paths, err := filepath.Glob(filepath.Join(config.JWTKeysDir, "*.pem"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	loaded := make(map[string]*signingKey, len(paths))
+	active := config.JWTSigningKID
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		key, err := loadKey(kid, path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		loaded[kid] = key
+		if config.JWTSigningKID == "" && key.private != nil {
+			active = kid
+		}
+	}
+
+	if key, ok := loaded[active]; !ok || key.private == nil {
+		return fmt.Errorf("no private signing key %q in %s", active, config.JWTKeysDir)
+	}
+
+	keysMu.Lock()
+	keys, signingKID = loaded, active
+	keysMu.Unlock()
+	return nil
+}
+
+func loadKey(kid, path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{kid: kid}
+	switch k := parsed.(type) {
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	return key, nil
+}
+
+func currentSigningKey() *signingKey {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	return keys[signingKID]
+}
+
+// VerificationKey is a jwt.Keyfunc that resolves the token's kid against
+// the keyring.
+func VerificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	keysMu.RLock()
+	key, ok := keys[kid]
+	keysMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+	return key.public, nil
+}
+
+// PublicKeys returns the public half of every key in the keyring.
+func PublicKeys() []JWK {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+
+	kids := make([]string, 0, len(keys))
+	for kid := range keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	jwks := make([]JWK, 0, len(kids))
+	for _, kid := range kids {
+		key := keys[kid]
+		jwk := JWK{Kid: kid, Alg: key.method.Alg()}
+		switch pub := key.public.(type) {
+		case ed25519.PublicKey:
+			jwk.Kty, jwk.Crv = "OKP", "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+)
+
+// Login failures are counted per account and per client IP:
+//
+//	login_fail:<subject>      failures in the current window
+//	login_lock:<subject>      present while the subject is locked out
+//	login_lockouts:<subject>  lockouts so far, drives the exponential backoff
+//
+// where subject is "user:<username>", "phone:<number>" or "ip:<address>".
+// Phone logins have their own namespace so that a phone number cannot
+// collide with a username.
+const (
+	SubjectUser  = "user"
+	SubjectPhone = "phone"
+	SubjectIP    = "ip"
+)
+
+// lockoutHistoryTTL is how long past lockouts count towards the backoff.
+const lockoutHistoryTTL = 24 * time.Hour
+
+// Lockout describes the login failure state of one subject.
+type Lockout struct {
+	Failures    int64
+	Lockouts    int64
+	LockedUntil time.Time
+}
+
+func lockoutSubject(kind, value string) string {
+	if kind == SubjectUser {
+		value = strings.ToLower(value)
+	}
+	return kind + ":" + value
+}
+
+func loginThreshold(kind string) int64 {
+	if kind == SubjectIP {
+		return int64(config.LoginMaxFailuresPerIP)
+	}
+	return int64(config.LoginMaxFailuresPerUser)
+}
+
+// CheckLoginLockout returns how long the login attempt must wait, or zero if
+// neither the account, a username or phone number as kind says, nor the IP
+// is locked out.
+func CheckLoginLockout(ctx context.Context, kind, account, ip string) (time.Duration, error) {
+	pipe := config.RedisClient.Pipeline()
+	userTTL := pipe.PTTL(ctx, "login_lock:"+lockoutSubject(kind, account))
+	var ipTTL *redis.DurationCmd
+	if ip != "" {
+		ipTTL = pipe.PTTL(ctx, "login_lock:"+lockoutSubject(SubjectIP, ip))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	wait := userTTL.Val()
+	if ipTTL != nil && ipTTL.Val() > wait {
+		wait = ipTTL.Val()
+	}
+	// PTTL reports negative values for missing keys.
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, nil
+}
+
+// RecordLoginFailure counts a failed login and locks out the account and/or
+// IP once they reach their threshold. Each further lockout within
+// lockoutHistoryTTL doubles the lockout duration, up to config.LoginLockoutMax.
+func RecordLoginFailure(ctx context.Context, kind, account, ip string) error {
+	if err := recordFailure(ctx, kind, account); err != nil {
+		return err
+	}
+	if ip != "" {
+		return recordFailure(ctx, SubjectIP, ip)
+	}
+	return nil
+}
+
+// failureScript counts a failure in KEYS[1] and starts the window of ARGV[1]
+// milliseconds with the first one, in one step so that the counter cannot be
+// left without an expiry.
+var failureScript = redis.NewScript(`
+local failures = redis.call('INCR', KEYS[1])
+if failures == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return failures
+`)
+
+func recordFailure(ctx context.Context, kind, value string) error {
+	subject := lockoutSubject(kind, value)
+	failKey := "login_fail:" + subject
+
+	failures, err := failureScript.Run(ctx, config.RedisClient, []string{failKey}, config.LoginFailureWindow.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if failures < loginThreshold(kind) {
+		return nil
+	}
+
+	lockouts, err := config.RedisClient.Incr(ctx, "login_lockouts:"+subject).Result()
+	if err != nil {
+		return err
+	}
+	duration := config.LoginLockoutBase
+	for i := int64(1); i < lockouts && duration < config.LoginLockoutMax; i++ {
+		duration *= 2
+	}
+	if duration > config.LoginLockoutMax {
+		duration = config.LoginLockoutMax
+	}
+
+	pipe := config.RedisClient.TxPipeline()
+	pipe.Expire(ctx, "login_lockouts:"+subject, lockoutHistoryTTL)
+	pipe.Set(ctx, "login_lock:"+subject, 1, duration)
+	pipe.Del(ctx, failKey)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RecordLoginSuccess resets the failure state of an account. The IP state is
+// left alone, since many users may share an address.
+func RecordLoginSuccess(ctx context.Context, kind, account string) error {
+	subject := lockoutSubject(kind, account)
+	return config.RedisClient.Del(ctx, "login_fail:"+subject, "login_lockouts:"+subject).Err()
+}
+
+// GetLoginLockout reports the failure state of a username, phone number or
+// IP.
+func GetLoginLockout(ctx context.Context, kind, value string) (*Lockout, error) {
+	subject := lockoutSubject(kind, value)
+	pipe := config.RedisClient.Pipeline()
+	failures := pipe.Get(ctx, "login_fail:"+subject)
+	lockouts := pipe.Get(ctx, "login_lockouts:"+subject)
+	ttl := pipe.PTTL(ctx, "login_lock:"+subject)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	lockout := &Lockout{}
+	lockout.Failures, _ = strconv.ParseInt(failures.Val(), 10, 64)
+	lockout.Lockouts, _ = strconv.ParseInt(lockouts.Val(), 10, 64)
+	if ttl.Val() > 0 {
+		lockout.LockedUntil = time.Now().Add(ttl.Val())
+	}
+	return lockout, nil
+}
+
+// ClearLoginLockout lifts any lockout of a username, phone number or IP and
+// forgets its failure history.
+func ClearLoginLockout(ctx context.Context, kind, value string) error {
+	subject := lockoutSubject(kind, value)
+	return config.RedisClient.Del(ctx, "login_fail:"+subject, "login_lockouts:"+subject, "login_lock:"+subject).Err()
+}
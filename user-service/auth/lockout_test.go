@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+)
+
+func TestLoginLockout(t *testing.T) {
+	mr := testenv.Setup(t)
+	config.LoginMaxFailuresPerUser = 3
+	config.LoginMaxFailuresPerIP = 100
+	config.LoginFailureWindow = 15 * time.Minute
+	config.LoginLockoutBase = time.Minute
+	config.LoginLockoutMax = time.Hour
+	ctx := context.Background()
+
+	if err := RecordLoginFailure(ctx, SubjectPhone, "+8613800000000", "192.0.2.1"); err != nil {
+		t.Fatal(err)
+	}
+	// The first failure starts the window.
+	if ttl := mr.TTL("login_fail:phone:+8613800000000"); ttl != 15*time.Minute {
+		t.Fatalf("failure counter TTL %v, want 15m", ttl)
+	}
+	for i := 0; i < 2; i++ {
+		if err := RecordLoginFailure(ctx, SubjectPhone, "+8613800000000", "192.0.2.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if wait, err := CheckLoginLockout(ctx, SubjectPhone, "+8613800000000", ""); err != nil || wait != time.Minute {
+		t.Fatalf("phone: wait %v, %v, want 1m", wait, err)
+	}
+	// A username equal to the phone number is a different subject.
+	if wait, err := CheckLoginLockout(ctx, SubjectUser, "+8613800000000", ""); err != nil || wait != 0 {
+		t.Fatalf("username: wait %v, %v, want none", wait, err)
+	}
+
+	if err := ClearLoginLockout(ctx, SubjectPhone, "+8613800000000"); err != nil {
+		t.Fatal(err)
+	}
+	if wait, err := CheckLoginLockout(ctx, SubjectPhone, "+8613800000000", ""); err != nil || wait != 0 {
+		t.Fatalf("after clearing: wait %v, %v", wait, err)
+	}
+}
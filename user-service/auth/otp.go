@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+)
+
+// One-time codes sent to a phone are kept as an HMAC digest, never in clear:
+//
+//	otp:<purpose>:<phone>           hash {digest, attempts}, expires with the code
+//	otp_cooldown:<purpose>:<phone>  blocks resending until it expires
+const (
+	PurposeLogin         = "login"
+	PurposeDeleteAccount = "delete_account"
+)
+
+var (
+	ErrCodeInvalid = errors.New("invalid or expired code")
+	// ErrCodeAttemptsExceeded is returned once a code has been guessed wrong
+	// config.OTPMaxAttempts times; the code is discarded.
+	ErrCodeAttemptsExceeded = errors.New("too many wrong codes")
+)
+
+// CooldownError is returned when a code was requested again too soon.
+type CooldownError struct {
+	Wait time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("code already sent, retry in %d seconds", int(e.Wait.Seconds())+1)
+}
+
+func otpKey(purpose, phone string) string         { return "otp:" + purpose + ":" + phone }
+func otpCooldownKey(purpose, phone string) string { return "otp_cooldown:" + purpose + ":" + phone }
+
+// verifyScript counts an attempt and compares digests. It returns 1 on a
+// match, consuming the code, 0 on a mismatch and -1 once the attempts are
+// exhausted or the code is missing.
+var verifyScript = redis.NewScript(`
+local digest = redis.call('HGET', KEYS[1], 'digest')
+if not digest then
+  return -1
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+if attempts > tonumber(ARGV[2]) then
+  redis.call('DEL', KEYS[1])
+  return -1
+end
+if digest == ARGV[1] then
+  redis.call('DEL', KEYS[1])
+  return 1
+end
+return 0
+`)
+
+// IssueCode generates a numeric code for phone, stores its digest and
+// returns it for delivery. A *CooldownError is returned while a previous
+// code is too recent.
+func IssueCode(ctx context.Context, purpose, phone string) (string, error) {
+	ok, err := config.RedisClient.SetNX(ctx, otpCooldownKey(purpose, phone), 1, config.OTPResendCooldown).Result()
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		wait, err := config.RedisClient.PTTL(ctx, otpCooldownKey(purpose, phone)).Result()
+		if err != nil {
+			return "", err
+		}
+		return "", &CooldownError{Wait: wait}
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+
+	pipe := config.RedisClient.TxPipeline()
+	pipe.Del(ctx, otpKey(purpose, phone))
+	pipe.HSet(ctx, otpKey(purpose, phone), "digest", digestCode(purpose, phone, code), "attempts", 0)
+	pipe.Expire(ctx, otpKey(purpose, phone), config.OTPCodeTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// VerifyCode checks code against the one issued for phone. A correct code
+// can only be used once.
+func VerifyCode(ctx context.Context, purpose, phone, code string) error {
+	res, err := verifyScript.Run(ctx, config.RedisClient, []string{otpKey(purpose, phone)},
+		digestCode(purpose, phone, code), config.OTPMaxAttempts).Int()
+	if err != nil {
+		return err
+	}
+	switch res {
+	case 1:
+		return nil
+	case 0:
+		return ErrCodeInvalid
+	default:
+		return ErrCodeAttemptsExceeded
+	}
+}
+
+// digestCode keys the digest with config.OTPSecret, since the code space is
+// far too small for a plain hash to hide it.
+func digestCode(purpose, phone, code string) string {
+	mac := hmac.New(sha256.New, config.OTPSecret)
+	mac.Write([]byte(purpose + "\x00" + phone + "\x00" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+)
+
+func setupOTP(t *testing.T) {
+	t.Helper()
+	testenv.Setup(t)
+	config.OTPCodeTTL = 5 * time.Minute
+	config.OTPResendCooldown = time.Minute
+	config.OTPMaxAttempts = 3
+	config.OTPSecret = []byte("test secret")
+}
+
+func TestIssueCodeCooldown(t *testing.T) {
+	setupOTP(t)
+	ctx := context.Background()
+
+	code, err := IssueCode(ctx, PurposeLogin, "+8613800000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[0-9]{6}$`).MatchString(code) {
+		t.Fatalf("code %q is not 6 digits", code)
+	}
+
+	_, err = IssueCode(ctx, PurposeLogin, "+8613800000000")
+	var cooldown *CooldownError
+	if !errors.As(err, &cooldown) || cooldown.Wait <= 0 || cooldown.Wait > time.Minute {
+		t.Fatalf("second code: got %v, want a cooldown of at most a minute", err)
+	}
+
+	// The cooldown is per phone.
+	if _, err := IssueCode(ctx, PurposeLogin, "+8613900000000"); err != nil {
+		t.Fatalf("other phone: %v", err)
+	}
+}
+
+func TestVerifyCodeSingleUse(t *testing.T) {
+	setupOTP(t)
+	ctx := context.Background()
+
+	code, err := IssueCode(ctx, PurposeLogin, "+8613800000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCode(ctx, PurposeLogin, "+8613900000000", code); !errors.Is(err, ErrCodeAttemptsExceeded) {
+		t.Fatalf("code of another phone: got %v", err)
+	}
+	if err := VerifyCode(ctx, PurposeLogin, "+8613800000000", code); err != nil {
+		t.Fatalf("correct code: %v", err)
+	}
+	if err := VerifyCode(ctx, PurposeLogin, "+8613800000000", code); err == nil {
+		t.Fatal("code accepted twice")
+	}
+}
+
+func TestVerifyCodeAttempts(t *testing.T) {
+	setupOTP(t)
+	ctx := context.Background()
+
+	code, err := IssueCode(ctx, PurposeLogin, "+8613800000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	for i := 0; i < config.OTPMaxAttempts; i++ {
+		if err := VerifyCode(ctx, PurposeLogin, "+8613800000000", wrong); !errors.Is(err, ErrCodeInvalid) {
+			t.Fatalf("wrong code %d: got %v, want ErrCodeInvalid", i, err)
+		}
+	}
+	// The code is discarded once the attempts are used up, so even the right
+	// code fails now.
+	if err := VerifyCode(ctx, PurposeLogin, "+8613800000000", code); !errors.Is(err, ErrCodeAttemptsExceeded) {
+		t.Fatalf("after %d wrong codes: got %v, want ErrCodeAttemptsExceeded", config.OTPMaxAttempts, err)
+	}
+}
+
+func TestVerifyCodeExpires(t *testing.T) {
+	setupOTP(t)
+	mr := testenv.Setup(t)
+	ctx := context.Background()
+
+	code, err := IssueCode(ctx, PurposeLogin, "+8613800000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr.FastForward(config.OTPCodeTTL + time.Second)
+	if err := VerifyCode(ctx, PurposeLogin, "+8613800000000", code); err == nil {
+		t.Fatal("expired code accepted")
+	}
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrRefreshTokenReused is returned when a refresh token that was already
+	// rotated is presented again. The whole token family is revoked, since
+	// either the client or an attacker holds a stolen copy.
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+)
+
+// Refresh tokens are opaque random strings. Redis only stores their SHA-256
+// digest, so a dump of Redis cannot be replayed:
+//
+//	refresh_token:<digest>      hash {user_id, family, rotated}
+//	refresh_family:<family>     digest of the family's current token
+//	user_refresh_families:<id>  set of the user's live families
+//
+// A family is the chain of tokens produced by one login; rotating a token
+// keeps the family and marks the old token as rotated.
+func refreshTokenKey(digest string) string  { return "refresh_token:" + digest }
+func refreshFamilyKey(family string) string { return "refresh_family:" + family }
+func userFamiliesKey(userID uint) string {
+	return "user_refresh_families:" + strconv.FormatUint(uint64(userID), 10)
+}
+
+// rotateScript marks a refresh token as used and returns its owner. The
+// rotated counter is above 1 when the token had already been used.
+var rotateScript = redis.NewScript(`
+local userID = redis.call('HGET', KEYS[1], 'user_id')
+if not userID then
+  return false
+end
+local rotated = redis.call('HINCRBY', KEYS[1], 'rotated', 1)
+return {userID, redis.call('HGET', KEYS[1], 'family'), rotated}
+`)
+
+// IssueRefreshToken creates a refresh token for userID. An empty family
+// starts a new one, as on login.
+func IssueRefreshToken(ctx context.Context, userID uint, family string) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	if family == "" {
+		var err error
+		if family, err = randomID(); err != nil {
+			return "", err
+		}
+	}
+
+	digest := digestToken(token)
+	ttl := config.RefreshTokenTTL
+	pipe := config.RedisClient.TxPipeline()
+	pipe.HSet(ctx, refreshTokenKey(digest), "user_id", userID, "family", family, "rotated", 0)
+	pipe.Expire(ctx, refreshTokenKey(digest), ttl)
+	pipe.Set(ctx, refreshFamilyKey(family), digest, ttl)
+	pipe.SAdd(ctx, userFamiliesKey(userID), family)
+	pipe.Expire(ctx, userFamiliesKey(userID), ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RotateRefreshToken consumes token and returns its owner and family
+// together with a replacement token in the same family.
+func RotateRefreshToken(ctx context.Context, token string) (uint, string, string, error) {
+	res, err := rotateScript.Run(ctx, config.RedisClient, []string{refreshTokenKey(digestToken(token))}).Slice()
+	if errors.Is(err, redis.Nil) {
+		return 0, "", "", ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return 0, "", "", err
+	}
+	userID, err := strconv.ParseUint(res[0].(string), 10, 32)
+	if err != nil {
+		return 0, "", "", err
+	}
+	family := res[1].(string)
+	if res[2].(int64) > 1 {
+		// A reused token means it leaked; end the whole session.
+		if err := revokeFamily(ctx, uint(userID), family); err != nil {
+			return 0, "", "", err
+		}
+		if err := RevokeSession(ctx, uint(userID), family); err != nil && !errors.Is(err, ErrSessionNotFound) {
+			return 0, "", "", err
+		}
+		return 0, "", "", ErrRefreshTokenReused
+	}
+
+	// A logout may have revoked the family after this token was issued.
+	current, err := config.RedisClient.Get(ctx, refreshFamilyKey(family)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, "", "", err
+	}
+	if current != digestToken(token) {
+		return 0, "", "", ErrInvalidRefreshToken
+	}
+
+	next, err := IssueRefreshToken(ctx, uint(userID), family)
+	if err != nil {
+		return 0, "", "", err
+	}
+	return uint(userID), family, next, nil
+}
+
+// RevokeRefreshToken revokes the family token belongs to, provided it
+// belongs to userID. Unknown tokens are ignored.
+func RevokeRefreshToken(ctx context.Context, userID uint, token string) error {
+	vals, err := config.RedisClient.HMGet(ctx, refreshTokenKey(digestToken(token)), "user_id", "family").Result()
+	if err != nil {
+		return err
+	}
+	owner, _ := vals[0].(string)
+	family, _ := vals[1].(string)
+	if family == "" || owner != strconv.FormatUint(uint64(userID), 10) {
+		return nil
+	}
+	return revokeFamily(ctx, userID, family)
+}
+
+// RevokeAllRefreshTokens revokes every refresh token family of userID.
+func RevokeAllRefreshTokens(ctx context.Context, userID uint) error {
+	families, err := config.RedisClient.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	for _, family := range families {
+		if err := revokeFamily(ctx, userID, family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func revokeFamily(ctx context.Context, userID uint, family string) error {
+	digest, err := config.RedisClient.Get(ctx, refreshFamilyKey(family)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	pipe := config.RedisClient.TxPipeline()
+	if digest != "" {
+		pipe.Del(ctx, refreshTokenKey(digest))
+	}
+	pipe.Del(ctx, refreshFamilyKey(family))
+	pipe.SRem(ctx, userFamiliesKey(userID), family)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func digestToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/revocation"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+)
+
+// RevokeAccessToken blacklists a single access token, e.g. on logout.
+func RevokeAccessToken(ctx context.Context, token *AccessToken) error {
+	return revocation.RevokeToken(ctx, config.RedisClient, token.ID, token.ExpiresAt)
+}
+
+// SignOutEverywhere revokes every session, access and refresh token of
+// userID. The api-gateway rejects the revoked access tokens on their next use.
+func SignOutEverywhere(ctx context.Context, userID uint) error {
+	if err := revocation.RevokeUser(ctx, config.RedisClient, uint32(userID), config.AccessTokenTTL); err != nil {
+		return err
+	}
+	if err := RevokeAllRefreshTokens(ctx, userID); err != nil {
+		return err
+	}
+	return config.DB.WithContext(ctx).Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
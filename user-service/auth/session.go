@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/revocation"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"gorm.io/gorm"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// sessionTouchInterval throttles LastSeenAt updates to one per session and
+// interval, so busy clients do not write to MySQL on every request.
+const sessionTouchInterval = time.Minute
+
+// SessionInfo describes the device a login happens on.
+type SessionInfo struct {
+	DeviceName string
+	UserAgent  string
+	IP         string
+}
+
+// StartSession records a new login of userID and issues its first access and
+// refresh tokens.
+func StartSession(ctx context.Context, userID uint, role string, info SessionInfo) (*AccessToken, string, error) {
+	sessionID, err := randomID()
+	if err != nil {
+		return nil, "", err
+	}
+	access, err := IssueAccessToken(userID, role, sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	session := model.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		DeviceName: truncate(info.DeviceName, 100),
+		UserAgent:  truncate(info.UserAgent, 255),
+		IP:         info.IP,
+		TokenID:    access.ID,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err := config.DB.WithContext(ctx).Create(&session).Error; err != nil {
+		return nil, "", err
+	}
+
+	refreshToken, err := IssueRefreshToken(ctx, userID, sessionID)
+	if err != nil {
+		return nil, "", err
+	}
+	return access, refreshToken, nil
+}
+
+// ContinueSession issues a new access token for an existing session after
+// its refresh token was rotated.
+func ContinueSession(ctx context.Context, sessionID string, userID uint, role, ip string) (*AccessToken, error) {
+	access, err := IssueAccessToken(userID, role, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	res := config.DB.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		Updates(map[string]interface{}{"token_id": access.ID, "last_seen_at": time.Now(), "ip": ip})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrSessionNotFound
+	}
+	return access, nil
+}
+
+// RevokeSession signs out one session of userID: its refresh token stops
+// working and the api-gateway rejects its access tokens.
+func RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	res := config.DB.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", sessionID, userID).
+		Update("revoked_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+	if err := revocation.RevokeSession(ctx, config.RedisClient, sessionID, config.AccessTokenTTL); err != nil {
+		return err
+	}
+	return revokeFamily(ctx, userID, sessionID)
+}
+
+// ActiveSessions returns the query of the sessions of userID that can
+// still refresh.
+func ActiveSessions(ctx context.Context, userID uint) *gorm.DB {
+	return config.DB.WithContext(ctx).Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL AND last_seen_at > ?", userID, time.Now().Add(-config.RefreshTokenTTL))
+}
+
+// TouchSession records activity on a session.
+func TouchSession(ctx context.Context, sessionID string) error {
+	ok, err := config.RedisClient.SetNX(ctx, "session_seen:"+sessionID, 1, sessionTouchInterval).Result()
+	if err != nil || !ok {
+		return err
+	}
+	return config.DB.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("last_seen_at", time.Now()).Error
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
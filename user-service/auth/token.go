@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+)
+
+// AccessToken is a signed JWT together with the claims the caller needs to
+// track or revoke it.
+type AccessToken struct {
+	Token     string
+	ID        string
+	ExpiresAt time.Time
+}
+
+// IssueAccessToken signs a short-lived access token for userID with role,
+// belonging to the login session sessionID.
+func IssueAccessToken(userID uint, role, sessionID string) (*AccessToken, error) {
+	jti, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(config.AccessTokenTTL)
+
+	// iat has millisecond precision, so that a sign-out earlier in the same
+	// second does not revoke the token.
+	key := currentSigningKey()
+	token := jwt.NewWithClaims(key.method, jwt.MapClaims{
+		"user_id": userID,
+		"role":    role,
+		"sid":     sessionID,
+		"jti":     jti,
+		"iat":     float64(now.UnixMilli()) / 1000,
+		"exp":     expiresAt.Unix(),
+	})
+	token.Header["kid"] = key.kid
+	tokenStr, err := token.SignedString(key.private)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessToken{Token: tokenStr, ID: jti, ExpiresAt: expiresAt}, nil
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
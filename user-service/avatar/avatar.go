@@ -0,0 +1,109 @@
+// Package avatar turns uploaded profile pictures into square JPEG images of
+// fixed sizes.
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"slices"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/storage"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Sizes of the generated images in pixels.
+const (
+	Size          = 512
+	ThumbnailSize = 128
+)
+
+// maxPixels bounds the decoded size, so small files that decompress into
+// huge images are rejected before they are decoded.
+const maxPixels = 40_000_000
+
+// Formats are the accepted image formats, as named by image.DecodeConfig.
+var Formats = []string{"jpeg", "png", "gif", "webp"}
+
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// Images are the encoded results of Process.
+type Images struct {
+	Avatar    []byte
+	Thumbnail []byte
+}
+
+// Process decodes data, crops it to a centered square and encodes it as
+// JPEG at Size and ThumbnailSize; smaller images are not enlarged.
+// Re-encoding also drops any metadata of the
+// upload, such as the location in EXIF tags.
+func Process(data []byte) (*Images, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if !slices.Contains(Formats, format) {
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("image of %dx%d pixels is too large", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	square := centerSquare(src.Bounds())
+
+	avatar, err := render(src, square, Size)
+	if err != nil {
+		return nil, err
+	}
+	thumbnail, err := render(src, square, ThumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Images{Avatar: avatar, Thumbnail: thumbnail}, nil
+}
+
+func centerSquare(b image.Rectangle) image.Rectangle {
+	side := min(b.Dx(), b.Dy())
+	x := b.Min.X + (b.Dx()-side)/2
+	y := b.Min.Y + (b.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
+
+// render scales the rect part of src to a size x size JPEG. Transparent
+// areas become white, since JPEG has no alpha channel.
+func render(src image.Image, rect image.Rectangle, size int) ([]byte, error) {
+	side := min(size, rect.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, rect, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ImageKey and ThumbnailKey are the storage keys of the images of the avatar
+// stored under prefix.
+func ImageKey(prefix string) string     { return prefix + ".jpg" }
+func ThumbnailKey(prefix string) string { return prefix + "_thumb.jpg" }
+
+// Delete removes both images of the avatar stored under prefix.
+func Delete(ctx context.Context, store storage.Storage, prefix string) error {
+	if err := store.Delete(ctx, ImageKey(prefix)); err != nil {
+		return err
+	}
+	return store.Delete(ctx, ThumbnailKey(prefix))
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"log"
+	"time"
+)
+
+var (
+	// AccountDeletionGracePeriod is how long a deleted account can still be
+	// restored by CancelAccountDeletion before it is purged.
+	AccountDeletionGracePeriod time.Duration
+	// AccountPurgeInterval is how often accounts past their grace period are
+	// purged.
+	AccountPurgeInterval time.Duration
+	// AccountPurgeMode is "anonymize" to keep an anonymized, soft-deleted user
+	// row for records that reference it, or "delete" to remove it entirely.
+	AccountPurgeMode string
+)
+
+func InitAccount() {
+	AccountDeletionGracePeriod = getEnvAsDuration("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour)
+	AccountPurgeInterval = getEnvAsDuration("ACCOUNT_PURGE_INTERVAL", time.Hour)
+	AccountPurgeMode = getEnv("ACCOUNT_PURGE_MODE", "anonymize")
+	if AccountPurgeMode != "anonymize" && AccountPurgeMode != "delete" {
+		log.Fatalf("ACCOUNT_PURGE_MODE must be anonymize or delete, got %q", AccountPurgeMode)
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+var (
+	// RegionDataFile is a JSON file with the region hierarchy addresses are
+	// validated against. The dataset bundled with package region is used
+	// when it is empty.
+	RegionDataFile string
+	// DeliveryZonesDir holds the GeoJSON files with the delivery zones of
+	// the stores. No address is deliverable while it is empty.
+	DeliveryZonesDir string
+
+	// GeocoderProvider selects the geo.Geocoder ("offline" unless HTTP
+	// providers are added) and GeocoderGazetteerFile replaces the bundled
+	// gazetteer of the offline provider.
+	GeocoderProvider      string
+	GeocoderGazetteerFile string
+)
+
+func InitAddress() {
+	RegionDataFile = getEnv("REGION_DATA_FILE", "")
+	DeliveryZonesDir = getEnv("DELIVERY_ZONES_DIR", "")
+	GeocoderProvider = getEnv("GEOCODER_PROVIDER", "offline")
+	GeocoderGazetteerFile = getEnv("GEOCODER_GAZETTEER_FILE", "")
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+)
+
+var (
+	// AccessTokenTTL is the lifetime of the JWT access tokens returned by
+	// Login and RefreshToken.
+	AccessTokenTTL time.Duration
+	// RefreshTokenTTL is the lifetime of a refresh token. Every successful
+	// refresh rotates the token and restarts this period.
+	RefreshTokenTTL time.Duration
+
+	// JWTKeysDir holds the PEM encoded JWT keys, one per file named <kid>.pem.
+	// Files with only a public key keep verifying tokens of retired keys.
+	JWTKeysDir string
+	// JWTSigningKID selects the signing key. When empty, the last private key
+	// in lexical order signs, so a new key can be rolled out by adding a file.
+	JWTSigningKID string
+	// JWTKeysReloadInterval controls how often JWTKeysDir is re-read; zero
+	// disables reloading.
+	JWTKeysReloadInterval time.Duration
+
+	// LoginMaxFailuresPerUser and LoginMaxFailuresPerIP are the failed logins
+	// within LoginFailureWindow that trigger a lockout.
+	LoginMaxFailuresPerUser int
+	LoginMaxFailuresPerIP   int
+	LoginFailureWindow      time.Duration
+	// LoginLockoutBase is the first lockout duration; it doubles with every
+	// repeated lockout up to LoginLockoutMax.
+	LoginLockoutBase time.Duration
+	LoginLockoutMax  time.Duration
+
+	// BootstrapAdmins are usernames promoted to the admin role at startup,
+	// so a fresh deployment has someone who can manage roles.
+	BootstrapAdmins []string
+
+	// OTPCodeTTL is how long a code sent by SMS stays valid.
+	OTPCodeTTL time.Duration
+	// OTPResendCooldown is the minimum time between two codes to one phone.
+	OTPResendCooldown time.Duration
+	// OTPMaxAttempts is how many wrong guesses discard a code.
+	OTPMaxAttempts int
+	// OTPSecret keys the digests of stored codes. It must be shared by all
+	// user-service replicas.
+	OTPSecret []byte
+	// PasswordResetTTL is how long a password reset token stays valid.
+	PasswordResetTTL time.Duration
+
+	// TOTPEncryptionKey encrypts TOTP secrets at rest (base64, 32 bytes).
+	// Two-factor authentication cannot be enabled while it is unset.
+	TOTPEncryptionKey []byte
+	// TOTPIssuer is the account issuer shown in authenticator apps.
+	TOTPIssuer string
+	// TwoFactorChallengeTTL is how long the second login step may take.
+	TwoFactorChallengeTTL time.Duration
+
+	// PasswordHashAlgorithm hashes new passwords ("argon2id" or "bcrypt").
+	// Hashes of the other algorithm keep verifying and are replaced on the
+	// next successful login, as are hashes with outdated parameters.
+	PasswordHashAlgorithm string
+	// Argon2Memory (KiB), Argon2Time and Argon2Threads are the argon2id cost
+	// parameters; BcryptCost is the bcrypt cost.
+	Argon2Memory  int
+	Argon2Time    int
+	Argon2Threads int
+	BcryptCost    int
+	// PasswordMinLength is the minimum length of a new password.
+	PasswordMinLength int
+	// PasswordDenylistFile lists breached passwords that are refused, one
+	// per line.
+	PasswordDenylistFile string
+
+	// SMSSender selects the sms.Sender implementation ("log" or "file") and
+	// SMSFilePath is where the file sender writes.
+	SMSSender   string
+	SMSFilePath string
+)
+
+func InitAuth() {
+	AccessTokenTTL = getEnvAsDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+	RefreshTokenTTL = getEnvAsDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+	JWTKeysDir = getEnv("JWT_KEYS_DIR", "keys")
+	JWTSigningKID = getEnv("JWT_SIGNING_KID", "")
+	JWTKeysReloadInterval = getEnvAsDuration("JWT_KEYS_RELOAD_INTERVAL", time.Minute)
+
+	LoginMaxFailuresPerUser = getEnvAsInt("LOGIN_MAX_FAILURES_PER_USER", 5)
+	LoginMaxFailuresPerIP = getEnvAsInt("LOGIN_MAX_FAILURES_PER_IP", 20)
+	LoginFailureWindow = getEnvAsDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute)
+	LoginLockoutBase = getEnvAsDuration("LOGIN_LOCKOUT_BASE", time.Minute)
+	LoginLockoutMax = getEnvAsDuration("LOGIN_LOCKOUT_MAX", time.Hour)
+
+	BootstrapAdmins = nil
+	for _, name := range strings.Split(getEnv("ADMIN_USERNAMES", ""), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			BootstrapAdmins = append(BootstrapAdmins, name)
+		}
+	}
+	if len(BootstrapAdmins) > 0 {
+		err := DB.Model(&model.User{}).Where("username IN ?", BootstrapAdmins).Update("role", "admin").Error
+		if err != nil {
+			log.Fatalf("Failed to promote admins: %v", err)
+		}
+	}
+
+	OTPCodeTTL = getEnvAsDuration("OTP_CODE_TTL", 5*time.Minute)
+	OTPResendCooldown = getEnvAsDuration("OTP_RESEND_COOLDOWN", time.Minute)
+	OTPMaxAttempts = getEnvAsInt("OTP_MAX_ATTEMPTS", 5)
+	OTPSecret = []byte(getEnv("OTP_SECRET", ""))
+	if len(OTPSecret) == 0 {
+		log.Println("OTP_SECRET is not set, using a random key; codes will not survive a restart")
+		OTPSecret = make([]byte, 32)
+		if _, err := rand.Read(OTPSecret); err != nil {
+			log.Fatalf("Failed to generate OTP secret: %v", err)
+		}
+	}
+
+	PasswordResetTTL = getEnvAsDuration("PASSWORD_RESET_TTL", 15*time.Minute)
+
+	if key := getEnv("TOTP_ENCRYPTION_KEY", ""); key != "" {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(decoded) != 32 {
+			log.Fatalf("TOTP_ENCRYPTION_KEY must be 32 bytes, base64 encoded")
+		}
+		TOTPEncryptionKey = decoded
+	}
+	TOTPIssuer = getEnv("TOTP_ISSUER", "Yixi Grocery")
+	TwoFactorChallengeTTL = getEnvAsDuration("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute)
+
+	PasswordHashAlgorithm = getEnv("PASSWORD_HASH_ALGORITHM", "argon2id")
+	Argon2Memory = getEnvAsInt("ARGON2_MEMORY_KIB", 19*1024)
+	Argon2Time = getEnvAsInt("ARGON2_TIME", 2)
+	Argon2Threads = getEnvAsInt("ARGON2_THREADS", 1)
+	BcryptCost = getEnvAsInt("BCRYPT_COST", 10)
+	PasswordMinLength = getEnvAsInt("PASSWORD_MIN_LENGTH", 8)
+	PasswordDenylistFile = getEnv("PASSWORD_DENYLIST_FILE", "")
+
+	SMSSender = getEnv("SMS_SENDER", "log")
+	SMSFilePath = getEnv("SMS_FILE_PATH", "sms.log")
+}
+
+// getEnvAsDuration retrieves an environment variable as a time.Duration or returns a default value
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+		log.Printf("Invalid duration %q for %s, using %s", value, key, defaultValue)
+	}
+	return defaultValue
+}
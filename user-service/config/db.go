@@ -41,7 +41,7 @@ func InitDB() {
 	if err != nil {
 		log.Fatalf("Failed to connect to MySQL: %v", err)
 	}
-	err = DB.AutoMigrate(&model.User{}, &model.Address{})
+	err = DB.AutoMigrate(model.All...)
 	if err != nil {
 		return
 	}
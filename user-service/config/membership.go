@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+var (
+	// MembershipTiersFile is a JSON file with the tier definitions. The
+	// built-in tiers of package membership are used when it is empty.
+	MembershipTiersFile string
+	// MembershipReviewInterval is how often all users' tiers are
+	// re-evaluated.
+	MembershipReviewInterval time.Duration
+	// MembershipEventStream is the Redis stream tier changes are published
+	// to; empty disables publishing.
+	MembershipEventStream string
+)
+
+func InitMembership() {
+	MembershipTiersFile = getEnv("MEMBERSHIP_TIERS_FILE", "")
+	MembershipReviewInterval = getEnvAsDuration("MEMBERSHIP_REVIEW_INTERVAL", 24*time.Hour)
+	MembershipEventStream = getEnv("MEMBERSHIP_EVENT_STREAM", "membership:tier_changes")
+}
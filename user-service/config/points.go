@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+var (
+	// PointsLifetime is how long granted points stay valid; zero keeps them
+	// forever.
+	PointsLifetime time.Duration
+	// PointsExpiryInterval is how often expired points are removed.
+	PointsExpiryInterval time.Duration
+	// PointsExpiringSoonWindow is the default look-ahead of
+	// GetExpiringPoints.
+	PointsExpiringSoonWindow time.Duration
+)
+
+func InitPoints() {
+	PointsLifetime = getEnvAsDuration("POINTS_LIFETIME", 365*24*time.Hour)
+	PointsExpiryInterval = getEnvAsDuration("POINTS_EXPIRY_INTERVAL", time.Hour)
+	PointsExpiringSoonWindow = getEnvAsDuration("POINTS_EXPIRING_SOON_WINDOW", 30*24*time.Hour)
+}
@@ -0,0 +1,24 @@
+package config
+
+var (
+	// ReferralInviterPoints and ReferralInviteePoints are granted to both
+	// sides of a referral once the invitee completes a first order.
+	ReferralInviterPoints int
+	ReferralInviteePoints int
+	// ReferralInviterCoupon and ReferralInviteeCoupon name the coupon
+	// templates issued with the rewards; empty issues none. Coupons are
+	// issued by the consumer of ReferralEventStream.
+	ReferralInviterCoupon string
+	ReferralInviteeCoupon string
+	// ReferralEventStream is the Redis stream rewarded referrals are
+	// published to; empty disables publishing.
+	ReferralEventStream string
+)
+
+func InitReferral() {
+	ReferralInviterPoints = getEnvAsInt("REFERRAL_INVITER_POINTS", 200)
+	ReferralInviteePoints = getEnvAsInt("REFERRAL_INVITEE_POINTS", 100)
+	ReferralInviterCoupon = getEnv("REFERRAL_INVITER_COUPON", "")
+	ReferralInviteeCoupon = getEnv("REFERRAL_INVITEE_COUPON", "")
+	ReferralEventStream = getEnv("REFERRAL_EVENT_STREAM", "referral:rewards")
+}
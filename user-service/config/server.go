@@ -0,0 +1,64 @@
+package config
+
+import (
+	"log"
+	"net"
+	"strings"
+)
+
+var (
+	// GRPCListenAddr is the address the gRPC server listens on.
+	GRPCListenAddr string
+
+	// TLSCertFile and TLSKeyFile enable TLS on the gRPC server. With
+	// TLSClientCAFile set as well, clients must present a certificate signed
+	// by one of its CAs.
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSClientCAFile string
+
+	// ServiceTokens are the credentials of internal services. A caller that
+	// presents one in the x-service-token metadata may act on behalf of the
+	// user named in the identity metadata it forwards.
+	ServiceTokens []string
+
+	// TrustedGateways are the networks of the api-gateway replicas. Only
+	// calls from them may name the end user's address in the x-client-ip
+	// metadata; other callers are identified by their peer address. The
+	// default trusts the private networks the services run in.
+	TrustedGateways []*net.IPNet
+)
+
+func InitServer() {
+	GRPCListenAddr = getEnv("GRPC_LISTEN_ADDR", ":8081")
+	TLSCertFile = getEnv("TLS_CERT_FILE", "")
+	TLSKeyFile = getEnv("TLS_KEY_FILE", "")
+	TLSClientCAFile = getEnv("TLS_CLIENT_CA_FILE", "")
+
+	ServiceTokens = nil
+	for _, token := range strings.Split(getEnv("SERVICE_TOKENS", ""), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			ServiceTokens = append(ServiceTokens, token)
+		}
+	}
+
+	TrustedGateways = nil
+	gateways := getEnv("TRUSTED_GATEWAYS", "127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7")
+	for _, gateway := range strings.Split(gateways, ",") {
+		if gateway = strings.TrimSpace(gateway); gateway == "" {
+			continue
+		}
+		if !strings.Contains(gateway, "/") {
+			if strings.Contains(gateway, ":") {
+				gateway += "/128"
+			} else {
+				gateway += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(gateway)
+		if err != nil {
+			log.Fatalf("Invalid TRUSTED_GATEWAYS entry %q: %v", gateway, err)
+		}
+		TrustedGateways = append(TrustedGateways, network)
+	}
+}
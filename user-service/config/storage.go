@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+var (
+	// StorageBackend selects where uploads are kept ("local" or "s3").
+	StorageBackend string
+	// StoragePublicURL is the base URL uploads are served from.
+	StoragePublicURL string
+	// StorageLocalDir is the directory of the local backend.
+	StorageLocalDir string
+
+	// S3Endpoint, S3Region, S3Bucket, S3AccessKeyID and S3SecretAccessKey
+	// configure the s3 backend. S3PathStyle is needed by most S3 stand-ins
+	// such as MinIO.
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PathStyle       bool
+
+	// AvatarMaxBytes is the largest accepted avatar upload.
+	AvatarMaxBytes int
+)
+
+func InitStorage() {
+	StorageBackend = getEnv("STORAGE_BACKEND", "local")
+	StoragePublicURL = getEnv("STORAGE_PUBLIC_URL", "/media")
+	StorageLocalDir = getEnv("STORAGE_LOCAL_DIR", "media")
+
+	S3Endpoint = getEnv("S3_ENDPOINT", "")
+	S3Region = getEnv("S3_REGION", "us-east-1")
+	S3Bucket = getEnv("S3_BUCKET", "")
+	S3AccessKeyID = getEnv("S3_ACCESS_KEY_ID", "")
+	S3SecretAccessKey = getEnv("S3_SECRET_ACCESS_KEY", "")
+	S3PathStyle = getEnvAsBool("S3_PATH_STYLE", true)
+
+	AvatarMaxBytes = getEnvAsInt("AVATAR_MAX_BYTES", 5<<20)
+}
+
+// getEnvAsBool retrieves an environment variable as a boolean or returns a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
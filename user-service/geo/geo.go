@@ -0,0 +1,86 @@
+// Package geo turns address text into coordinates and back, and suggests
+// places while the user types.
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Precision of a result, from coarse to fine.
+const (
+	PrecisionCity     = "city"
+	PrecisionDistrict = "district"
+	PrecisionPlace    = "place"
+)
+
+var ErrNotFound = errors.New("no matching place")
+
+// Result is a place found by a Geocoder.
+type Result struct {
+	// Label is the place as shown to users.
+	Label     string
+	Province  string
+	City      string
+	District  string
+	Street    string
+	Latitude  float64
+	Longitude float64
+	Precision string
+}
+
+// Geocoder resolves places. Implementations must be safe for concurrent
+// use.
+type Geocoder interface {
+	// Geocode returns the best match for an address, or ErrNotFound.
+	Geocode(ctx context.Context, address string) (*Result, error)
+	// Autocomplete suggests up to limit places for partially typed text.
+	Autocomplete(ctx context.Context, query string, limit int) ([]Result, error)
+	// Reverse returns the place at the coordinates, or ErrNotFound.
+	Reverse(ctx context.Context, lat, lng float64) (*Result, error)
+}
+
+// Config selects and configures the provider.
+type Config struct {
+	Provider string
+	// GazetteerFile replaces the gazetteer bundled with the offline
+	// provider.
+	GazetteerFile string
+}
+
+// Factory creates a provider from the configuration.
+type Factory func(cfg Config) (Geocoder, error)
+
+var (
+	mu        sync.Mutex
+	factories = map[string]Factory{
+		"offline": func(cfg Config) (Geocoder, error) { return NewOffline(cfg.GazetteerFile) },
+	}
+)
+
+// Register makes a provider available to New. HTTP based providers register
+// themselves from an init function in their own file.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = f
+}
+
+// New creates the configured provider.
+func New(cfg Config) (Geocoder, error) {
+	mu.Lock()
+	f, ok := factories[cfg.Provider]
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	mu.Unlock()
+	if !ok {
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown geocoder provider %q, available: %v", cfg.Provider, names)
+	}
+	return f(cfg)
+}
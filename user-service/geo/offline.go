@@ -0,0 +1,218 @@
+package geo
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// bundledGazetteer lists the major cities, their districts and a few
+// landmarks. It is meant for tests and air-gapped deployments; a fuller
+// gazetteer in the same format can be configured.
+//
+//go:embed gazetteer.json
+var bundledGazetteer []byte
+
+// Reverse reports a landmark within maxLandmarkDistance and otherwise the
+// nearest district within maxDistrictDistance, in kilometres.
+const (
+	maxLandmarkDistance = 1
+	maxDistrictDistance = 20
+)
+
+// entry is one place of the gazetteer. Kind is "city", "district" or
+// "landmark".
+type entry struct {
+	Name     string  `json:"name"`
+	Kind     string  `json:"kind"`
+	Province string  `json:"province"`
+	City     string  `json:"city"`
+	District string  `json:"district"`
+	Street   string  `json:"street"`
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+}
+
+func (e *entry) result() Result {
+	r := Result{
+		Province:  e.Province,
+		City:      e.City,
+		District:  e.District,
+		Street:    e.Street,
+		Latitude:  e.Lat,
+		Longitude: e.Lng,
+	}
+	switch e.Kind {
+	case "city":
+		r.Precision = PrecisionCity
+	case "district":
+		r.Precision = PrecisionDistrict
+	default:
+		r.Precision = PrecisionPlace
+	}
+	city := e.City
+	if city == e.Province {
+		city = ""
+	}
+	r.Label = e.Province + city + e.District
+	if r.Precision == PrecisionPlace {
+		r.Label += e.Name
+	}
+	return r
+}
+
+// rank orders the kinds from the most to the least precise.
+func (e *entry) rank() int {
+	switch e.Kind {
+	case "landmark":
+		return 0
+	case "district":
+		return 1
+	}
+	return 2
+}
+
+// Offline is a Geocoder that looks places up in a gazetteer held in memory.
+type Offline struct {
+	entries []entry
+}
+
+// NewOffline loads the gazetteer from file, or the bundled one when file is
+// empty.
+func NewOffline(file string) (*Offline, error) {
+	data := bundledGazetteer
+	if file != "" {
+		var err error
+		if data, err = os.ReadFile(file); err != nil {
+			return nil, err
+		}
+	}
+	var entries []entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse gazetteer: %w", err)
+	}
+	for i, e := range entries {
+		if e.Name == "" || e.City == "" {
+			return nil, fmt.Errorf("gazetteer entry %d: name and city are required", i)
+		}
+	}
+	return &Offline{entries: entries}, nil
+}
+
+// Geocode finds the most precise entry named in the address. Entries must
+// lie in the city the address names, if it names one of the gazetteer.
+func (o *Offline) Geocode(ctx context.Context, address string) (*Result, error) {
+	address = strings.Join(strings.Fields(address), "")
+	var best *entry
+	for i := range o.entries {
+		e := &o.entries[i]
+		if !strings.Contains(address, e.Name) || !o.cityMatches(address, e) {
+			continue
+		}
+		if best == nil || e.rank() < best.rank() || (e.rank() == best.rank() && len(e.Name) > len(best.Name)) {
+			best = e
+		}
+	}
+	if best == nil {
+		return nil, ErrNotFound
+	}
+	r := best.result()
+	return &r, nil
+}
+
+// cityMatches rules out entries of another city with the same name, such as
+// the 鼓楼区 of different cities.
+func (o *Offline) cityMatches(address string, e *entry) bool {
+	if e.Kind == "city" || strings.Contains(address, e.City) {
+		return true
+	}
+	for _, other := range o.entries {
+		if other.Kind == "city" && strings.Contains(address, other.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// Autocomplete prefers entries starting with the query over entries merely
+// containing it, and precise entries over coarse ones.
+func (o *Offline) Autocomplete(ctx context.Context, query string, limit int) ([]Result, error) {
+	query = strings.Join(strings.Fields(query), "")
+	if query == "" || limit <= 0 {
+		return nil, nil
+	}
+	type match struct {
+		e     *entry
+		score int
+	}
+	var matches []match
+	for i := range o.entries {
+		e := &o.entries[i]
+		r := e.result()
+		switch {
+		case strings.HasPrefix(e.Name, query):
+			matches = append(matches, match{e, 0})
+		case strings.Contains(e.Name, query):
+			matches = append(matches, match{e, 1})
+		case strings.Contains(r.Label, query):
+			matches = append(matches, match{e, 2})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].e.rank() < matches[j].e.rank()
+	})
+	results := make([]Result, 0, min(limit, len(matches)))
+	for _, m := range matches[:min(limit, len(matches))] {
+		results = append(results, m.e.result())
+	}
+	return results, nil
+}
+
+// Reverse returns a nearby landmark, or else the nearest district.
+func (o *Offline) Reverse(ctx context.Context, lat, lng float64) (*Result, error) {
+	best := o.nearest("landmark", lat, lng, maxLandmarkDistance)
+	if best == nil {
+		best = o.nearest("district", lat, lng, maxDistrictDistance)
+	}
+	if best == nil {
+		return nil, ErrNotFound
+	}
+	r := best.result()
+	r.Latitude, r.Longitude = lat, lng
+	return &r, nil
+}
+
+// nearest returns the entry of the kind closest to the coordinates, or nil
+// if none is within maxDistance kilometres.
+func (o *Offline) nearest(kind string, lat, lng, maxDistance float64) *entry {
+	var best *entry
+	bestDistance := maxDistance
+	for i := range o.entries {
+		e := &o.entries[i]
+		if e.Kind != kind {
+			continue
+		}
+		if d := distance(lat, lng, e.Lat, e.Lng); d <= bestDistance {
+			best, bestDistance = e, d
+		}
+	}
+	return best
+}
+
+// distance is the great-circle distance in kilometres.
+func distance(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadius = 6371
+	rad := math.Pi / 180
+	dLat, dLng := (lat2-lat1)*rad, (lng2-lng1)*rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
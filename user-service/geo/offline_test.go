@@ -0,0 +1,154 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newOffline(t *testing.T, gazetteer string) *Offline {
+	t.Helper()
+	file := ""
+	if gazetteer != "" {
+		file = filepath.Join(t.TempDir(), "gazetteer.json")
+		if err := os.WriteFile(file, []byte(gazetteer), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	o, err := NewOffline(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestGeocode(t *testing.T) {
+	o := newOffline(t, "")
+	ctx := context.Background()
+	for _, tc := range []struct {
+		address   string
+		label     string
+		precision string
+	}{
+		{"上海市黄浦区中山东一路外滩18号", "上海市黄浦区外滩", PrecisionPlace},
+		{"上海市 黄浦区 人民 广场 1号", "上海市黄浦区人民广场", PrecisionPlace},
+		{"北京市朝阳区建国路88号", "北京市朝阳区", PrecisionDistrict},
+		{"杭州市某某路1号", "浙江省杭州市", PrecisionCity},
+	} {
+		r, err := o.Geocode(ctx, tc.address)
+		if err != nil {
+			t.Errorf("%s: %v", tc.address, err)
+			continue
+		}
+		if r.Label != tc.label || r.Precision != tc.precision || r.Latitude == 0 || r.Longitude == 0 {
+			t.Errorf("%s: got %+v, want %s at %s precision", tc.address, r, tc.label, tc.precision)
+		}
+	}
+	if _, err := o.Geocode(ctx, "火星基地1号"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown place: got %v", err)
+	}
+}
+
+func TestGeocodeSameNamedDistricts(t *testing.T) {
+	o := newOffline(t, `[
+		{"name": "南京市", "kind": "city", "province": "江苏省", "city": "南京市", "lat": 32.06, "lng": 118.8},
+		{"name": "福州市", "kind": "city", "province": "福建省", "city": "福州市", "lat": 26.07, "lng": 119.3},
+		{"name": "鼓楼区", "kind": "district", "province": "江苏省", "city": "南京市", "district": "鼓楼区", "lat": 32.07, "lng": 118.77},
+		{"name": "鼓楼区", "kind": "district", "province": "福建省", "city": "福州市", "district": "鼓楼区", "lat": 26.08, "lng": 119.29}
+	]`)
+	ctx := context.Background()
+	for address, city := range map[string]string{
+		"江苏省南京市鼓楼区中山路1号": "南京市",
+		"福建省福州市鼓楼区五四路1号": "福州市",
+	} {
+		r, err := o.Geocode(ctx, address)
+		if err != nil || r.City != city || r.Precision != PrecisionDistrict {
+			t.Errorf("%s: got %+v, %v, want the 鼓楼区 of %s", address, r, err, city)
+		}
+	}
+	// Without a city the district is still found in one of them.
+	if r, err := o.Geocode(ctx, "鼓楼区中山路1号"); err != nil || r.District != "鼓楼区" {
+		t.Errorf("no city: got %+v, %v", r, err)
+	}
+	// A city of the gazetteer without such a district does not match
+	// another city's district.
+	o = newOffline(t, `[
+		{"name": "南京市", "kind": "city", "province": "江苏省", "city": "南京市", "lat": 32.06, "lng": 118.8},
+		{"name": "厦门市", "kind": "city", "province": "福建省", "city": "厦门市", "lat": 24.48, "lng": 118.09},
+		{"name": "鼓楼区", "kind": "district", "province": "江苏省", "city": "南京市", "district": "鼓楼区", "lat": 32.07, "lng": 118.77}
+	]`)
+	if r, err := o.Geocode(ctx, "厦门市鼓楼区1号"); err != nil || r.City != "厦门市" || r.Precision != PrecisionCity {
+		t.Errorf("other city: got %+v, %v, want 厦门市 at city precision", r, err)
+	}
+}
+
+func TestAutocomplete(t *testing.T) {
+	o := newOffline(t, "")
+	ctx := context.Background()
+
+	results, err := o.Autocomplete(ctx, "西", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Label)
+	}
+	// Prefix matches come first, landmarks before districts, then names
+	// merely containing the query.
+	want := []string{"浙江省杭州市西湖区西湖景区", "北京市西城区", "浙江省杭州市西湖区", "北京市丰台区北京西站"}
+	if len(names) < len(want) {
+		t.Fatalf("got %v, want %v first", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got %v, want %v first", names, want)
+		}
+	}
+
+	if results, _ := o.Autocomplete(ctx, "西", 2); len(results) != 2 {
+		t.Errorf("limit 2: got %d results", len(results))
+	}
+	if results, _ := o.Autocomplete(ctx, "  ", 10); len(results) != 0 {
+		t.Errorf("blank query: got %v", results)
+	}
+	// Queries also match the label, e.g. the city of a district.
+	if results, _ := o.Autocomplete(ctx, "深圳市南山", 10); len(results) == 0 || results[0].District != "南山区" {
+		t.Errorf("label match: got %v", results)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	o := newOffline(t, "")
+	ctx := context.Background()
+
+	r, err := o.Reverse(ctx, 31.2405, 121.4905)
+	if err != nil || r.Label != "上海市黄浦区外滩" || r.Latitude != 31.2405 || r.Longitude != 121.4905 {
+		t.Fatalf("near a landmark: got %+v, %v", r, err)
+	}
+	r, err = o.Reverse(ctx, 31.13, 121.38)
+	if err != nil || r.District != "闵行区" || r.Precision != PrecisionDistrict {
+		t.Fatalf("near a district: got %+v, %v", r, err)
+	}
+	if _, err := o.Reverse(ctx, 0.5, 0.5); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("in the ocean: got %v", err)
+	}
+}
+
+func TestNewOfflineInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"not json": `{`,
+		"no name":  `[{"kind": "city", "city": "南京市"}]`,
+		"no city":  `[{"name": "鼓楼区", "kind": "district"}]`,
+	} {
+		file := filepath.Join(t.TempDir(), "gazetteer.json")
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewOffline(file); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
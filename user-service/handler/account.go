@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *UserHandler) DeleteMyAccount(ctx context.Context, req *userProto.DeleteMyAccountRequest) (*userProto.DeleteMyAccountResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.DeleteMyAccountResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.DeleteMyAccountResponse{Code: 404, Message: "User not found"}, err
+	}
+	// Accounts created by phone login have a random password, so a code sent
+	// to the verified phone proves the user as well.
+	if req.PhoneCode != "" {
+		if !user.PhoneVerified {
+			return &userProto.DeleteMyAccountResponse{Code: 400, Message: "No verified phone"}, status.Error(codes.FailedPrecondition, "account has no verified phone")
+		}
+		err := auth.VerifyCode(ctx, auth.PurposeDeleteAccount, user.Phone, req.PhoneCode)
+		if errors.Is(err, auth.ErrCodeInvalid) || errors.Is(err, auth.ErrCodeAttemptsExceeded) {
+			return &userProto.DeleteMyAccountResponse{Code: 400, Message: "Invalid or expired code"}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err != nil {
+			return &userProto.DeleteMyAccountResponse{Code: 500, Message: "Failed to verify code"}, err
+		}
+	} else if !utils.CheckPassword(req.Password, user.Password) {
+		return &userProto.DeleteMyAccountResponse{Code: 400, Message: "Invalid password"}, status.Error(codes.InvalidArgument, "password is incorrect")
+	}
+	if user.TOTPEnabled {
+		ok, err := checkSecondFactor(ctx, &user, req.Code)
+		if err != nil {
+			return &userProto.DeleteMyAccountResponse{Code: 500, Message: "Failed to verify code"}, err
+		}
+		if !ok {
+			return &userProto.DeleteMyAccountResponse{Code: 400, Message: "Invalid code"}, status.Error(codes.InvalidArgument, "invalid code")
+		}
+	}
+
+	// A repeated request keeps the original schedule.
+	if user.DeletionScheduledAt == nil {
+		scheduledAt := time.Now().Add(config.AccountDeletionGracePeriod)
+		if err := config.DB.WithContext(ctx).Model(&user).Update("deletion_scheduled_at", scheduledAt).Error; err != nil {
+			return &userProto.DeleteMyAccountResponse{Code: 500, Message: "Failed to delete account"}, err
+		}
+		user.DeletionScheduledAt = &scheduledAt
+	}
+	if err := auth.SignOutEverywhere(ctx, user.ID); err != nil {
+		return &userProto.DeleteMyAccountResponse{Code: 500, Message: "Failed to revoke tokens"}, err
+	}
+
+	return &userProto.DeleteMyAccountResponse{
+		Code:                0,
+		Message:             "Account scheduled for deletion",
+		DeletionScheduledAt: user.DeletionScheduledAt.Unix(),
+	}, nil
+}
+
+func (h *UserHandler) SendAccountDeletionCode(ctx context.Context, req *userProto.SendAccountDeletionCodeRequest) (*userProto.SendAccountDeletionCodeResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.SendAccountDeletionCodeResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.SendAccountDeletionCodeResponse{Code: 404, Message: "User not found"}, err
+	}
+	if !user.PhoneVerified {
+		return &userProto.SendAccountDeletionCodeResponse{Code: 400, Message: "No verified phone"}, status.Error(codes.FailedPrecondition, "account has no verified phone")
+	}
+
+	code, err := auth.IssueCode(ctx, auth.PurposeDeleteAccount, user.Phone)
+	var cooldown *auth.CooldownError
+	if errors.As(err, &cooldown) {
+		return &userProto.SendAccountDeletionCodeResponse{Code: 429, Message: "Code already sent"}, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		return &userProto.SendAccountDeletionCodeResponse{Code: 500, Message: "Failed to create code"}, err
+	}
+
+	message := fmt.Sprintf("Your Yixi Grocery code to delete your account is %s. It expires in %d minutes.", code, int(config.OTPCodeTTL.Minutes()))
+	if err := h.SMS.Send(ctx, user.Phone, message); err != nil {
+		return &userProto.SendAccountDeletionCodeResponse{Code: 500, Message: "Failed to send code"}, err
+	}
+
+	return &userProto.SendAccountDeletionCodeResponse{
+		Code:        0,
+		Message:     "Success",
+		ExpiresIn:   int64(config.OTPCodeTTL.Seconds()),
+		ResendAfter: int64(config.OTPResendCooldown.Seconds()),
+	}, nil
+}
+
+func (h *UserHandler) CancelAccountDeletion(ctx context.Context, req *userProto.CancelAccountDeletionRequest) (*userProto.CancelAccountDeletionResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.CancelAccountDeletionResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	res := config.DB.WithContext(ctx).Model(&model.User{}).
+		Where("id = ? AND deletion_scheduled_at > ?", userID, time.Now()).
+		Update("deletion_scheduled_at", nil)
+	if res.Error != nil {
+		return &userProto.CancelAccountDeletionResponse{Code: 500, Message: "Failed to cancel deletion"}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return &userProto.CancelAccountDeletionResponse{Code: 409, Message: "Account is not scheduled for deletion"},
+			status.Error(codes.FailedPrecondition, "account is not scheduled for deletion")
+	}
+	return &userProto.CancelAccountDeletionResponse{Code: 0, Message: "Success"}, nil
+}
+
+// dataExport is the JSON document returned by ExportMyData.
+type dataExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Profile    exportProfile   `json:"profile"`
+	Addresses  []exportAddress `json:"addresses"`
+	Sessions   []exportSession `json:"sessions"`
+	Points     exportPoints    `json:"points"`
+}
+
+type exportProfile struct {
+	ID                  uint       `json:"id"`
+	Username            string     `json:"username"`
+	Phone               string     `json:"phone"`
+	PhoneVerified       bool       `json:"phone_verified"`
+	Address             string     `json:"address"`
+	Nickname            string     `json:"nickname"`
+	Email               string     `json:"email"`
+	Gender              string     `json:"gender"`
+	Birthday            string     `json:"birthday"`
+	AvatarURL           string     `json:"avatar_url"`
+	ReferralCode        string     `json:"referral_code"`
+	Role                string     `json:"role"`
+	TwoFactorEnabled    bool       `json:"two_factor_enabled"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+}
+
+type exportAddress struct {
+	ID            uint      `json:"id"`
+	ReceiverName  string    `json:"receiver_name"`
+	Phone         string    `json:"phone"`
+	AddressDetail string    `json:"address_detail"`
+	Province      string    `json:"province"`
+	City          string    `json:"city"`
+	District      string    `json:"district"`
+	Street        string    `json:"street"`
+	Building      string    `json:"building"`
+	Postcode      string    `json:"postcode"`
+	Latitude      *float64  `json:"latitude,omitempty"`
+	Longitude     *float64  `json:"longitude,omitempty"`
+	IsDefault     bool      `json:"is_default"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type exportSession struct {
+	DeviceName string     `json:"device_name"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type exportPoints struct {
+	Balance     int                 `json:"balance"`
+	Tier        string              `json:"tier"`
+	TierChanges []exportTierChange  `json:"tier_changes"`
+	History     []exportPointsEntry `json:"history"`
+}
+
+type exportTierChange struct {
+	FromTier  string    `json:"from_tier"`
+	ToTier    string    `json:"to_tier"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type exportPointsEntry struct {
+	Type         string    `json:"type"`
+	Delta        int       `json:"delta"`
+	Reason       string    `json:"reason"`
+	ReferenceID  *string   `json:"reference_id,omitempty"`
+	BalanceAfter int       `json:"balance_after"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (h *UserHandler) ExportMyData(ctx context.Context, req *userProto.ExportMyDataRequest) (*httpbody.HttpBody, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	db := config.DB.WithContext(ctx)
+	var user model.User
+	if err := db.First(&user, userID).Error; err != nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	var addresses []model.Address
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+	var sessions []model.Session
+	if err := db.Where("user_id = ?", userID).Order("created_at").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	var ledger []model.PointsTransaction
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&ledger).Error; err != nil {
+		return nil, err
+	}
+	var tierChanges []model.TierChange
+	if err := db.Where("user_id = ?", userID).Order("id").Find(&tierChanges).Error; err != nil {
+		return nil, err
+	}
+
+	export := dataExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: exportProfile{
+			ID:                  user.ID,
+			Username:            user.Username,
+			Phone:               user.Phone,
+			PhoneVerified:       user.PhoneVerified,
+			Address:             user.Address,
+			Nickname:            user.Nickname,
+			Email:               user.Email,
+			Gender:              user.Gender,
+			Birthday:            utils.FormatBirthday(user.Birthday),
+			AvatarURL:           user.AvatarURL,
+			ReferralCode:        stringValue(user.ReferralCode),
+			Role:                user.Role,
+			TwoFactorEnabled:    user.TOTPEnabled,
+			CreatedAt:           user.CreatedAt,
+			UpdatedAt:           user.UpdatedAt,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+		},
+		Addresses: []exportAddress{},
+		Sessions:  []exportSession{},
+		Points: exportPoints{
+			Balance:     user.Points,
+			Tier:        user.Tier,
+			TierChanges: []exportTierChange{},
+			History:     []exportPointsEntry{},
+		},
+	}
+	for _, a := range addresses {
+		export.Addresses = append(export.Addresses, exportAddress{
+			ID:            a.ID,
+			ReceiverName:  a.ReceiverName,
+			Phone:         a.Phone,
+			AddressDetail: a.AddressDetail,
+			Province:      a.Province,
+			City:          a.City,
+			District:      a.District,
+			Street:        a.Street,
+			Building:      a.Building,
+			Postcode:      a.Postcode,
+			Latitude:      a.Latitude,
+			Longitude:     a.Longitude,
+			IsDefault:     a.IsDefault,
+			CreatedAt:     a.CreatedAt,
+		})
+	}
+	for _, s := range sessions {
+		export.Sessions = append(export.Sessions, exportSession{
+			DeviceName: s.DeviceName,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			RevokedAt:  s.RevokedAt,
+		})
+	}
+
+	for _, c := range tierChanges {
+		export.Points.TierChanges = append(export.Points.TierChanges, exportTierChange{
+			FromTier:  c.FromTier,
+			ToTier:    c.ToTier,
+			CreatedAt: c.CreatedAt,
+		})
+	}
+	for _, e := range ledger {
+		export.Points.History = append(export.Points.History, exportPointsEntry{
+			Type:         e.Type,
+			Delta:        e.Delta,
+			Reason:       e.Reason,
+			ReferenceID:  e.ReferenceID,
+			BalanceAfter: e.BalanceAfter,
+			CreatedAt:    e.CreatedAt,
+		})
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &httpbody.HttpBody{ContentType: "application/json", Data: data}, nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/sms"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeletePhoneRegisteredAccount(t *testing.T) {
+	testenv.Setup(t)
+	config.OTPCodeTTL = 5 * time.Minute
+	config.OTPResendCooldown = time.Minute
+	config.OTPMaxAttempts = 3
+	config.OTPSecret = []byte("test secret")
+	config.AccountDeletionGracePeriod = 30 * 24 * time.Hour
+	config.AccessTokenTTL = 15 * time.Minute
+
+	sink := filepath.Join(t.TempDir(), "sms.log")
+	sender, err := sms.New("file", sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &UserHandler{SMS: sender}
+
+	user, err := registerByPhone(context.Background(), "+8613800000000", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := identity.NewContext(context.Background(), &identity.Identity{UserID: uint32(user.ID), Role: user.Role})
+
+	// The password was generated at registration and nobody knows it.
+	if _, err := h.DeleteMyAccount(ctx, &userProto.DeleteMyAccountRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("without proof: got %v, want InvalidArgument", err)
+	}
+
+	if _, err := h.SendAccountDeletionCode(ctx, &userProto.SendAccountDeletionCodeRequest{}); err != nil {
+		t.Fatalf("SendAccountDeletionCode: %v", err)
+	}
+	data, err := os.ReadFile(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := regexp.MustCompile(`delete your account is ([0-9]{6})`).FindStringSubmatch(string(data))
+	if m == nil {
+		t.Fatalf("no code in sink: %q", data)
+	}
+
+	wrong := "000000"
+	if m[1] == wrong {
+		wrong = "111111"
+	}
+	if _, err := h.DeleteMyAccount(ctx, &userProto.DeleteMyAccountRequest{PhoneCode: wrong}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("wrong code: got %v, want InvalidArgument", err)
+	}
+	resp, err := h.DeleteMyAccount(ctx, &userProto.DeleteMyAccountRequest{PhoneCode: m[1]})
+	if err != nil {
+		t.Fatalf("with code: %v", err)
+	}
+	if resp.DeletionScheduledAt <= time.Now().Unix() {
+		t.Errorf("deletion scheduled at %d", resp.DeletionScheduledAt)
+	}
+	var stored model.User
+	if err := config.DB.First(&stored, user.ID).Error; err != nil || stored.DeletionScheduledAt == nil {
+		t.Fatalf("deletion not scheduled: %v", err)
+	}
+}
+
+func TestSendAccountDeletionCodeNeedsVerifiedPhone(t *testing.T) {
+	testenv.Setup(t)
+	user := model.User{Username: "alice", Password: "x", Phone: "+8613800000000"}
+	if err := config.DB.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	h := &UserHandler{}
+	ctx := identity.NewContext(context.Background(), &identity.Identity{UserID: uint32(user.ID), Role: user.Role})
+
+	if _, err := h.SendAccountDeletionCode(ctx, &userProto.SendAccountDeletionCodeRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("unverified phone: got %v, want FailedPrecondition", err)
+	}
+}
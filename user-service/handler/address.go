@@ -0,0 +1,368 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/paging"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/region"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/zone"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxStreetLength = 100
+
+var postcodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+var addressesList = &paging.Spec{
+	Fields: map[string]paging.Field{
+		"id":            {Column: "id", Kind: paging.Int, Sortable: true},
+		"receiver_name": {Column: "receiver_name", Kind: paging.String, Sortable: true},
+		"phone":         {Column: "phone", Kind: paging.String},
+		"province":      {Column: "province", Kind: paging.String},
+		"city":          {Column: "city", Kind: paging.String},
+		"district":      {Column: "district", Kind: paging.String},
+		"is_default":    {Column: "is_default", Kind: paging.Bool, Sortable: true},
+		"created_at":    {Column: "created_at", Kind: paging.Time, Sortable: true},
+		"updated_at":    {Column: "updated_at", Kind: paging.Time, Sortable: true},
+	},
+	Key:             "id",
+	DefaultOrder:    "is_default desc, id",
+	DefaultPageSize: 50,
+	MaxPageSize:     100,
+}
+
+// addressInput holds the address fields shared by AddAddressRequest and
+// UpdateAddressRequest.
+type addressInput struct {
+	AddressDetail string
+	Place         region.Place
+	Street        string
+	Building      string
+	Postcode      string
+	Latitude      float64
+	Longitude     float64
+}
+
+// structured reports whether the client sent a structured address rather
+// than only AddressDetail.
+func (in *addressInput) structured() bool {
+	return in.Place.ProvinceCode != "" || in.Place.Province != ""
+}
+
+// setAddress validates the input and stores it in address. Structured input
+// is checked against the region dataset and AddressDetail is derived from
+// it; a bare AddressDetail is parsed on a best-effort basis.
+func (h *UserHandler) setAddress(address *model.Address, in addressInput) error {
+	if in.Latitude < -90 || in.Latitude > 90 || in.Longitude < -180 || in.Longitude > 180 {
+		return status.Error(codes.InvalidArgument, "latitude or longitude out of range")
+	}
+	if in.Postcode != "" && !postcodePattern.MatchString(in.Postcode) {
+		return status.Error(codes.InvalidArgument, "postcode must have 6 digits")
+	}
+	in.Street, in.Building = strings.TrimSpace(in.Street), strings.TrimSpace(in.Building)
+	if utf8.RuneCountInString(in.Street) > maxStreetLength || utf8.RuneCountInString(in.Building) > maxStreetLength {
+		return status.Errorf(codes.InvalidArgument, "street and building must be at most %d characters", maxStreetLength)
+	}
+
+	if in.structured() {
+		place, err := h.Regions.Resolve(in.Place)
+		if errors.Is(err, region.ErrUnknownRegion) || errors.Is(err, region.ErrRegionMismatch) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+		if place.City == "" || in.Street == "" {
+			return status.Error(codes.InvalidArgument, "city and street are required")
+		}
+		in.Place = place
+		in.AddressDetail = region.Format(place, in.Street, in.Building)
+	} else {
+		in.AddressDetail = strings.TrimSpace(in.AddressDetail)
+		if in.AddressDetail == "" {
+			return status.Error(codes.InvalidArgument, "address is required")
+		}
+		parsed := h.Regions.ParseAddress(in.AddressDetail)
+		in.Place, in.Street, in.Building = parsed.Place, parsed.Street, parsed.Building
+		if in.Postcode == "" {
+			in.Postcode = parsed.Postcode
+		}
+	}
+	if err := utils.ValidateAddress(in.AddressDetail); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	address.AddressDetail = in.AddressDetail
+	address.ProvinceCode, address.Province = in.Place.ProvinceCode, in.Place.Province
+	address.CityCode, address.City = in.Place.CityCode, in.Place.City
+	address.DistrictCode, address.District = in.Place.DistrictCode, in.Place.District
+	address.Street, address.Building, address.Postcode = in.Street, in.Building, in.Postcode
+	address.Latitude, address.Longitude = nil, nil
+	if in.Latitude != 0 || in.Longitude != 0 {
+		address.Latitude, address.Longitude = &in.Latitude, &in.Longitude
+	}
+	return nil
+}
+
+// updatableAddressFields are the UpdateAddressRequest fields UpdateAddress
+// may change.
+var updatableAddressFields = []string{
+	"receiver_name", "phone", "address_detail", "is_default",
+	"province_code", "province", "city_code", "city", "district_code", "district",
+	"street", "building", "postcode", "latitude", "longitude",
+}
+
+// addressUpdatePaths returns the fields to update. Without a mask, every
+// updatable field with a non-empty value is updated.
+func addressUpdatePaths(req *userProto.UpdateAddressRequest) ([]string, error) {
+	mask := req.UpdateMask
+	if len(mask.GetPaths()) == 0 {
+		var paths []string
+		msg := req.ProtoReflect()
+		for _, path := range updatableAddressFields {
+			if msg.Has(msg.Descriptor().Fields().ByName(protoreflect.Name(path))) {
+				paths = append(paths, path)
+			}
+		}
+		return paths, nil
+	}
+
+	if !mask.IsValid(req) {
+		return nil, status.Error(codes.InvalidArgument, "update_mask names unknown fields")
+	}
+	mask.Normalize()
+	for _, path := range mask.GetPaths() {
+		if !slices.Contains(updatableAddressFields, path) {
+			return nil, status.Errorf(codes.InvalidArgument, "field %s cannot be updated", path)
+		}
+	}
+	return mask.GetPaths(), nil
+}
+
+// errClearDefault rejects updates that unset the default address, which
+// would leave the user without one.
+var errClearDefault = status.Error(codes.InvalidArgument, "the default address cannot be unset, set another address as default instead")
+
+// locationColumns are the columns setAddress writes.
+var locationColumns = []string{
+	"address_detail", "province_code", "province", "city_code", "city", "district_code", "district",
+	"street", "building", "postcode", "latitude", "longitude",
+}
+
+// updateAddress applies the fields of req named in paths to address and
+// returns the columns it changed. The location is merged with the stored
+// one and validated as a whole; when it moves without new coordinates, the
+// old ones are dropped so that geocodeAddress looks them up again. It only
+// resolves and geocodes, the caller stores the result.
+func (h *UserHandler) updateAddress(ctx context.Context, address *model.Address, req *userProto.UpdateAddressRequest, paths []string) ([]string, error) {
+	has := func(path string) bool { return slices.Contains(paths, path) }
+
+	if has("is_default") && !req.IsDefault && address.IsDefault {
+		return nil, errClearDefault
+	}
+
+	var columns []string
+	if has("receiver_name") {
+		address.ReceiverName = strings.TrimSpace(req.ReceiverName)
+		if address.ReceiverName == "" {
+			return nil, status.Error(codes.InvalidArgument, "receiver_name must not be empty")
+		}
+		columns = append(columns, "receiver_name")
+	}
+	if has("phone") {
+		address.Phone = strings.TrimSpace(req.Phone)
+		if address.Phone == "" {
+			return nil, status.Error(codes.InvalidArgument, "phone must not be empty")
+		}
+		columns = append(columns, "phone")
+	}
+
+	structured := slices.ContainsFunc(paths, func(path string) bool {
+		return slices.Contains([]string{"province_code", "province", "city_code", "city", "district_code", "district", "street", "building"}, path)
+	})
+	coordinates := has("latitude") || has("longitude")
+	if coordinates && !(has("latitude") && has("longitude")) {
+		return nil, status.Error(codes.InvalidArgument, "latitude and longitude must be updated together")
+	}
+	if !structured && !coordinates && !has("address_detail") && !has("postcode") {
+		return columns, nil
+	}
+
+	in := addressInput{
+		AddressDetail: address.AddressDetail,
+		Place: region.Place{
+			ProvinceCode: address.ProvinceCode,
+			Province:     address.Province,
+			CityCode:     address.CityCode,
+			City:         address.City,
+			DistrictCode: address.DistrictCode,
+			District:     address.District,
+		},
+		Street:   address.Street,
+		Building: address.Building,
+		Postcode: address.Postcode,
+	}
+	if has("address_detail") && !structured {
+		// The address was typed again as one line, so it is parsed afresh.
+		in = addressInput{AddressDetail: req.AddressDetail}
+	}
+
+	// A changed level invalidates the levels below it unless they change
+	// too; the most specific code would otherwise override the new names.
+	changed := false
+	for _, l := range []struct {
+		codePath, namePath string
+		code, name         *string
+		newCode, newName   string
+	}{
+		{"province_code", "province", &in.Place.ProvinceCode, &in.Place.Province, req.ProvinceCode, req.Province},
+		{"city_code", "city", &in.Place.CityCode, &in.Place.City, req.CityCode, req.City},
+		{"district_code", "district", &in.Place.DistrictCode, &in.Place.District, req.DistrictCode, req.District},
+	} {
+		hasCode, hasName := has(l.codePath), has(l.namePath)
+		if hasCode || hasName || changed {
+			*l.code, *l.name = "", ""
+		}
+		if hasCode {
+			*l.code = l.newCode
+		}
+		if hasName {
+			*l.name = l.newName
+		}
+		changed = changed || hasCode || hasName
+	}
+	if has("street") {
+		in.Street = req.Street
+	}
+	if has("building") {
+		in.Building = req.Building
+	}
+	if has("postcode") {
+		in.Postcode = req.Postcode
+	}
+
+	switch {
+	case coordinates:
+		in.Latitude, in.Longitude = req.Latitude, req.Longitude
+	case structured || has("address_detail"):
+		// Moved; the coordinates are looked up below.
+	case address.Latitude != nil && address.Longitude != nil:
+		in.Latitude, in.Longitude = *address.Latitude, *address.Longitude
+	}
+	if err := h.setAddress(address, in); err != nil {
+		return nil, err
+	}
+	h.geocodeAddress(ctx, address)
+	return append(columns, locationColumns...), nil
+}
+
+// ownAddress loads an address of userID. Missing addresses are reported as
+// NotFound and those of other users as PermissionDenied.
+func ownAddress(tx *gorm.DB, id, userID uint32) (*model.Address, error) {
+	var address model.Address
+	if err := tx.First(&address, id).Error; err != nil {
+		return nil, notFoundOr(err, "address not found")
+	}
+	if address.UserID != uint(userID) {
+		return nil, status.Error(codes.PermissionDenied, "address belongs to another user")
+	}
+	return &address, nil
+}
+
+// addressToProto converts an address and reports whether it is
+// deliverable. Zones may change, so this is decided on every read.
+func (h *UserHandler) addressToProto(address *model.Address) *userProto.Address {
+	a := &userProto.Address{
+		Id:            uint32(address.ID),
+		UserId:        uint32(address.UserID),
+		ReceiverName:  address.ReceiverName,
+		Phone:         address.Phone,
+		AddressDetail: address.AddressDetail,
+		IsDefault:     address.IsDefault,
+		ProvinceCode:  address.ProvinceCode,
+		Province:      address.Province,
+		CityCode:      address.CityCode,
+		City:          address.City,
+		DistrictCode:  address.DistrictCode,
+		District:      address.District,
+		Street:        address.Street,
+		Building:      address.Building,
+		Postcode:      address.Postcode,
+	}
+	if address.Latitude != nil && address.Longitude != nil {
+		a.Latitude, a.Longitude = *address.Latitude, *address.Longitude
+		if z := h.Zones.Locate(zone.Point{Lng: a.Longitude, Lat: a.Latitude}); z != nil {
+			a.Deliverable, a.StoreId = true, z.StoreID
+		}
+	}
+	return a
+}
+
+func (h *UserHandler) CheckDeliverable(ctx context.Context, req *userProto.CheckDeliverableRequest) (*userProto.CheckDeliverableResponse, error) {
+	if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 ||
+		(req.Latitude == 0 && req.Longitude == 0) {
+		return &userProto.CheckDeliverableResponse{Code: 400, Message: "Invalid coordinates"},
+			status.Error(codes.InvalidArgument, "latitude and longitude are required and must be in range")
+	}
+
+	resp := &userProto.CheckDeliverableResponse{Code: 0, Message: "Success"}
+	if z := h.Zones.Locate(zone.Point{Lng: req.Longitude, Lat: req.Latitude}); z != nil {
+		resp.Deliverable, resp.StoreId, resp.ZoneName = true, z.StoreID, z.Name
+	}
+	return resp, nil
+}
+
+// addressBookTx runs fn in a transaction holding the lock on the user row.
+// This serializes all changes to one user's address book, so the user
+// always has exactly one default address once there is any address.
+func addressBookTx(ctx context.Context, userID uint32, fn func(tx *gorm.DB) error) error {
+	return config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, userID).Error; err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// clearOtherDefaults unsets the default flag of all addresses of the user
+// but the given one.
+func clearOtherDefaults(tx *gorm.DB, address *model.Address) error {
+	return tx.Model(&model.Address{}).
+		Where("user_id = ? AND id <> ? AND is_default = ?", address.UserID, address.ID, true).
+		Update("is_default", false).Error
+}
+
+// promoteDefault makes the most recently updated address the default if the
+// user has addresses but no default.
+func promoteDefault(tx *gorm.DB, userID uint) error {
+	var defaults int64
+	if err := tx.Model(&model.Address{}).Where("user_id = ? AND is_default = ?", userID, true).Count(&defaults).Error; err != nil {
+		return err
+	}
+	if defaults > 0 {
+		return nil
+	}
+	var next model.Address
+	err := tx.Where("user_id = ?", userID).Order("updated_at DESC, id DESC").First(&next).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Model(&next).Update("is_default", true).Error
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/authz"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestAddressOwnership(t *testing.T) {
+	testenv.Setup(t)
+	alice := model.User{Username: "alice", Password: "x", Role: string(authz.RoleCustomer)}
+	bob := model.User{Username: "bob", Password: "x", Role: string(authz.RoleCustomer)}
+	for _, u := range []*model.User{&alice, &bob} {
+		if err := config.DB.Create(u).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	address := model.Address{UserID: alice.ID, ReceiverName: "Alice", Phone: "13800000000", AddressDetail: "1 Main St", IsDefault: true}
+	if err := config.DB.Create(&address).Error; err != nil {
+		t.Fatal(err)
+	}
+	h := &UserHandler{}
+	ctx := identity.NewContext(context.Background(), &identity.Identity{UserID: uint32(bob.ID), Role: bob.Role})
+
+	for id, want := range map[uint32]codes.Code{uint32(address.ID): codes.PermissionDenied, 999: codes.NotFound} {
+		_, err := h.GetAddress(ctx, &userProto.GetAddressRequest{Id: id})
+		if status.Code(err) != want {
+			t.Errorf("GetAddress %d: got %v, want %v", id, err, want)
+		}
+		_, err = h.UpdateAddress(ctx, &userProto.UpdateAddressRequest{Id: id, Phone: "13900000000", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"phone"}}})
+		if status.Code(err) != want {
+			t.Errorf("UpdateAddress %d: got %v, want %v", id, err, want)
+		}
+		_, err = h.SetDefaultAddress(ctx, &userProto.SetDefaultAddressRequest{Id: id})
+		if status.Code(err) != want {
+			t.Errorf("SetDefaultAddress %d: got %v, want %v", id, err, want)
+		}
+		_, err = h.DeleteAddress(ctx, &userProto.DeleteAddressRequest{Id: id})
+		if status.Code(err) != want {
+			t.Errorf("DeleteAddress %d: got %v, want %v", id, err, want)
+		}
+	}
+
+	var stored model.Address
+	if err := config.DB.First(&stored, address.ID).Error; err != nil || stored.Phone != "13800000000" {
+		t.Fatalf("address of another user changed: %+v, %v", stored, err)
+	}
+}
+
+func TestUpdateAddressWritesMaskedFields(t *testing.T) {
+	testenv.Setup(t)
+	user := model.User{Username: "alice", Password: "x", Role: string(authz.RoleCustomer)}
+	if err := config.DB.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	home := model.Address{UserID: user.ID, ReceiverName: "Alice", Phone: "13800000000", AddressDetail: "1 Main St", IsDefault: true}
+	work := model.Address{UserID: user.ID, ReceiverName: "Alice", Phone: "13800000000", AddressDetail: "2 Side St"}
+	for _, a := range []*model.Address{&home, &work} {
+		if err := config.DB.Create(a).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := &UserHandler{}
+	ctx := identity.NewContext(context.Background(), &identity.Identity{UserID: uint32(user.ID), Role: user.Role})
+
+	resp, err := h.UpdateAddress(ctx, &userProto.UpdateAddressRequest{
+		Id:           uint32(work.ID),
+		ReceiverName: "Bob",
+		Phone:        "13900000000",
+		IsDefault:    true,
+		UpdateMask:   &fieldmaskpb.FieldMask{Paths: []string{"phone", "is_default"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Phone != "13900000000" || resp.Data.ReceiverName != "Alice" || !resp.Data.IsDefault {
+		t.Errorf("returned %+v", resp.Data)
+	}
+
+	var stored model.Address
+	if err := config.DB.First(&stored, work.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Phone != "13900000000" || stored.ReceiverName != "Alice" || stored.AddressDetail != "2 Side St" || !stored.IsDefault {
+		t.Errorf("stored %+v", stored)
+	}
+	var previous model.Address
+	if err := config.DB.First(&previous, home.ID).Error; err != nil || previous.IsDefault {
+		t.Errorf("previous default kept its flag: %v", err)
+	}
+}
+
+func TestUpdateAddressKeepsDefault(t *testing.T) {
+	testenv.Setup(t)
+	user := model.User{Username: "alice", Password: "x", Role: string(authz.RoleCustomer)}
+	if err := config.DB.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	home := model.Address{UserID: user.ID, ReceiverName: "Alice", Phone: "13800000000", AddressDetail: "1 Main St", IsDefault: true}
+	work := model.Address{UserID: user.ID, ReceiverName: "Alice", Phone: "13800000000", AddressDetail: "2 Side St"}
+	for _, a := range []*model.Address{&home, &work} {
+		if err := config.DB.Create(a).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := &UserHandler{}
+	ctx := identity.NewContext(context.Background(), &identity.Identity{UserID: uint32(user.ID), Role: user.Role})
+	unset := &fieldmaskpb.FieldMask{Paths: []string{"is_default"}}
+
+	resp, err := h.UpdateAddress(ctx, &userProto.UpdateAddressRequest{Id: uint32(home.ID), UpdateMask: unset})
+	if status.Code(err) != codes.InvalidArgument || resp.GetCode() != 400 {
+		t.Fatalf("unsetting the default: got %d, %v, want InvalidArgument", resp.GetCode(), err)
+	}
+	var stored model.Address
+	if err := config.DB.First(&stored, home.ID).Error; err != nil || !stored.IsDefault {
+		t.Fatalf("default flag cleared: %v", err)
+	}
+
+	// Unsetting the flag of another address changes nothing.
+	if _, err := h.UpdateAddress(ctx, &userProto.UpdateAddressRequest{Id: uint32(work.ID), UpdateMask: unset}); err != nil {
+		t.Fatalf("unsetting a non-default address: %v", err)
+	}
+}
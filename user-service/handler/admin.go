@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/authz"
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/paging"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/points"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// lockoutTarget picks the subject of a lockout request; exactly one of
+// username, phone and ip must be given. Phone numbers are normalized as at
+// login.
+func lockoutTarget(username, phone, ip string) (string, string, error) {
+	given := 0
+	for _, v := range []string{username, phone, ip} {
+		if v != "" {
+			given++
+		}
+	}
+	if given != 1 {
+		return "", "", status.Error(codes.InvalidArgument, "exactly one of username, phone and ip is required")
+	}
+	switch {
+	case username != "":
+		return auth.SubjectUser, username, nil
+	case phone != "":
+		normalized, ok := utils.NormalizePhone(phone)
+		if !ok {
+			return "", "", status.Error(codes.InvalidArgument, "invalid phone number")
+		}
+		return auth.SubjectPhone, normalized, nil
+	default:
+		return auth.SubjectIP, ip, nil
+	}
+}
+
+func (h *UserHandler) GetLoginLockout(ctx context.Context, req *userProto.GetLoginLockoutRequest) (*userProto.GetLoginLockoutResponse, error) {
+	kind, value, err := lockoutTarget(req.Username, req.Phone, req.Ip)
+	if err != nil {
+		return &userProto.GetLoginLockoutResponse{Code: 400, Message: "Invalid request"}, err
+	}
+
+	lockout, err := auth.GetLoginLockout(ctx, kind, value)
+	if err != nil {
+		return &userProto.GetLoginLockoutResponse{Code: 500, Message: "Failed to get lockout"}, err
+	}
+
+	data := &userProto.LoginLockout{
+		Subject:  kind + ":" + value,
+		Failures: lockout.Failures,
+		Lockouts: lockout.Lockouts,
+		Locked:   !lockout.LockedUntil.IsZero(),
+	}
+	if data.Locked {
+		data.LockedUntil = lockout.LockedUntil.Unix()
+	}
+	return &userProto.GetLoginLockoutResponse{Code: 0, Message: "Success", Data: data}, nil
+}
+
+func (h *UserHandler) ClearLoginLockout(ctx context.Context, req *userProto.ClearLoginLockoutRequest) (*userProto.ClearLoginLockoutResponse, error) {
+	kind, value, err := lockoutTarget(req.Username, req.Phone, req.Ip)
+	if err != nil {
+		return &userProto.ClearLoginLockoutResponse{Code: 400, Message: "Invalid request"}, err
+	}
+
+	if err := auth.ClearLoginLockout(ctx, kind, value); err != nil {
+		return &userProto.ClearLoginLockoutResponse{Code: 500, Message: "Failed to clear lockout"}, err
+	}
+	return &userProto.ClearLoginLockoutResponse{Code: 0, Message: "Success"}, nil
+}
+
+var usersList = &paging.Spec{
+	Fields: map[string]paging.Field{
+		"id":         {Column: "id", Kind: paging.Int, Sortable: true},
+		"username":   {Column: "username", Kind: paging.String, Sortable: true},
+		"phone":      {Column: "phone", Kind: paging.String},
+		"email":      {Column: "email", Kind: paging.String},
+		"role":       {Column: "role", Kind: paging.String},
+		"tier":       {Column: "tier", Kind: paging.String},
+		"points":     {Column: "points", Kind: paging.Int, Sortable: true},
+		"disabled":   {Column: "disabled", Kind: paging.Bool},
+		"created_at": {Column: "created_at", Kind: paging.Time, Sortable: true},
+	},
+	Key:             "id",
+	DefaultOrder:    "id",
+	DefaultPageSize: 50,
+	MaxPageSize:     500,
+}
+
+func (h *UserHandler) ListUsers(ctx context.Context, req *userProto.ListUsersRequest) (*userProto.ListUsersResponse, error) {
+	// Query matches username and phone prefixes. It is matched literally,
+	// so it does not go through the filter syntax, where "*" and quotes
+	// have a meaning.
+	query := config.DB.WithContext(ctx)
+	if req.Query != "" {
+		prefix := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(req.Query) + "%"
+		query = query.Where("username LIKE ? OR phone LIKE ?", prefix, prefix)
+	}
+
+	users, next, err := paging.Find[model.User](query, usersList, paging.Request{
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		Filter:    req.Filter,
+		OrderBy:   req.OrderBy,
+		Scope:     req.Query,
+	})
+	if errors.Is(err, paging.ErrInvalid) {
+		return &userProto.ListUsersResponse{Code: 400, Message: "Invalid list request"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &userProto.ListUsersResponse{Code: 500, Message: "Failed to list users"}, err
+	}
+
+	resp := &userProto.ListUsersResponse{Code: 0, Message: "Success", NextPageToken: next}
+	for i := range users {
+		resp.Users = append(resp.Users, userToProto(&users[i]))
+	}
+	return resp, nil
+}
+
+func (h *UserHandler) GetUser(ctx context.Context, req *userProto.GetUserRequest) (*userProto.GetUserResponse, error) {
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, req.UserId).Error; err != nil {
+		return &userProto.GetUserResponse{Code: 404, Message: "User not found"}, notFoundOr(err, "user not found")
+	}
+	return &userProto.GetUserResponse{Code: 0, Message: "Success", Data: userToProto(&user)}, nil
+}
+
+func (h *UserHandler) DisableUser(ctx context.Context, req *userProto.DisableUserRequest) (*userProto.DisableUserResponse, error) {
+	if id, err := caller(ctx); err == nil && id.UserID == req.UserId && !req.Enable {
+		return &userProto.DisableUserResponse{Code: 400, Message: "Cannot disable yourself"}, status.Error(codes.InvalidArgument, "cannot disable your own account")
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, req.UserId).Error; err != nil {
+		return &userProto.DisableUserResponse{Code: 404, Message: "User not found"}, notFoundOr(err, "user not found")
+	}
+
+	user.Disabled = !req.Enable
+	user.DisabledReason = ""
+	if user.Disabled {
+		user.DisabledReason = req.Reason
+	}
+	if err := config.DB.WithContext(ctx).Model(&user).Select("disabled", "disabled_reason").Updates(&user).Error; err != nil {
+		return &userProto.DisableUserResponse{Code: 500, Message: "Failed to update user"}, err
+	}
+	if user.Disabled {
+		if err := auth.SignOutEverywhere(ctx, user.ID); err != nil {
+			return &userProto.DisableUserResponse{Code: 500, Message: "Failed to sign out user"}, err
+		}
+	}
+	return &userProto.DisableUserResponse{Code: 0, Message: "Success", Data: userToProto(&user)}, nil
+}
+
+// SetUserRole changes the role of a user. Access tokens carry the role, so
+// the user is signed out everywhere to make a change take effect at once.
+func (h *UserHandler) SetUserRole(ctx context.Context, req *userProto.SetUserRoleRequest) (*userProto.SetUserRoleResponse, error) {
+	if !authz.ValidRole(req.Role) {
+		return &userProto.SetUserRoleResponse{Code: 400, Message: "Invalid role"}, status.Errorf(codes.InvalidArgument, "unknown role %q", req.Role)
+	}
+	// Admins cannot demote themselves, so there is always one left.
+	if id, err := caller(ctx); err == nil && id.UserID == req.UserId {
+		return &userProto.SetUserRoleResponse{Code: 400, Message: "Cannot change your own role"}, status.Error(codes.InvalidArgument, "cannot change your own role")
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, req.UserId).Error; err != nil {
+		return &userProto.SetUserRoleResponse{Code: 404, Message: "User not found"}, notFoundOr(err, "user not found")
+	}
+	if user.Role == req.Role {
+		return &userProto.SetUserRoleResponse{Code: 0, Message: "Success", Data: userToProto(&user)}, nil
+	}
+
+	previous := user.Role
+	user.Role = req.Role
+	if err := config.DB.WithContext(ctx).Model(&user).Update("role", user.Role).Error; err != nil {
+		return &userProto.SetUserRoleResponse{Code: 500, Message: "Failed to update user"}, err
+	}
+	if err := auth.SignOutEverywhere(ctx, user.ID); err != nil {
+		return &userProto.SetUserRoleResponse{Code: 500, Message: "Failed to sign out user"}, err
+	}
+	log.Printf("Role of user %d changed from %s to %s", user.ID, previous, user.Role)
+	return &userProto.SetUserRoleResponse{Code: 0, Message: "Success", Data: userToProto(&user)}, nil
+}
+
+func (h *UserHandler) AdjustPoints(ctx context.Context, req *userProto.AdjustPointsRequest) (*userProto.AdjustPointsResponse, error) {
+	if req.Delta == 0 {
+		return &userProto.AdjustPointsResponse{Code: 400, Message: "Delta must not be zero"}, status.Error(codes.InvalidArgument, "delta must not be zero")
+	}
+
+	if len(req.ReferenceId) > maxReferenceIDLength {
+		return &userProto.AdjustPointsResponse{Code: 400, Message: "Invalid reference ID"},
+			status.Errorf(codes.InvalidArgument, "reference_id must be at most %d characters", maxReferenceIDLength)
+	}
+
+	_, _, err := points.Apply(ctx, points.Change{
+		UserID:      uint(req.UserId),
+		Type:        model.PointsAdjust,
+		Delta:       int(req.Delta),
+		ReferenceID: req.ReferenceId,
+		Reason:      req.Reason,
+	})
+	switch {
+	case errors.Is(err, points.ErrUserNotFound):
+		return &userProto.AdjustPointsResponse{Code: 404, Message: "User not found"}, pointsError(err)
+	case errors.Is(err, points.ErrInsufficientPoints):
+		return &userProto.AdjustPointsResponse{Code: 400, Message: "Insufficient points"}, pointsError(err)
+	case errors.Is(err, points.ErrReferenceConflict):
+		return &userProto.AdjustPointsResponse{Code: 409, Message: "Reference ID already used"}, pointsError(err)
+	case err != nil:
+		return &userProto.AdjustPointsResponse{Code: 500, Message: "Failed to adjust points"}, pointsError(err)
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, req.UserId).Error; err != nil {
+		return &userProto.AdjustPointsResponse{Code: 404, Message: "User not found"}, notFoundOr(err, "user not found")
+	}
+	log.Printf("Points of user %d adjusted by %d: %s", user.ID, req.Delta, req.Reason)
+	return &userProto.AdjustPointsResponse{Code: 0, Message: "Success", Data: userToProto(&user)}, nil
+}
+
+// notFoundOr maps gorm.ErrRecordNotFound to a NotFound status and passes
+// other errors through.
+func notFoundOr(err error, msg string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, msg)
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/authz"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetUserRole(t *testing.T) {
+	testenv.Setup(t)
+	admin := model.User{Username: "admin", Password: "x", Role: string(authz.RoleAdmin)}
+	user := model.User{Username: "alice", Password: "x", Role: string(authz.RoleCustomer)}
+	for _, u := range []*model.User{&admin, &user} {
+		if err := config.DB.Create(u).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := &UserHandler{}
+	ctx := identity.NewContext(context.Background(), &identity.Identity{UserID: uint32(admin.ID), Role: admin.Role})
+
+	for _, tc := range []struct {
+		name string
+		req  *userProto.SetUserRoleRequest
+		want codes.Code
+	}{
+		{"unknown role", &userProto.SetUserRoleRequest{UserId: uint32(user.ID), Role: "root"}, codes.InvalidArgument},
+		{"service role", &userProto.SetUserRoleRequest{UserId: uint32(user.ID), Role: string(authz.RoleService)}, codes.InvalidArgument},
+		{"own role", &userProto.SetUserRoleRequest{UserId: uint32(admin.ID), Role: string(authz.RoleStaff)}, codes.InvalidArgument},
+		{"unknown user", &userProto.SetUserRoleRequest{UserId: 999, Role: string(authz.RoleStaff)}, codes.NotFound},
+		{"promote", &userProto.SetUserRoleRequest{UserId: uint32(user.ID), Role: string(authz.RoleStaff)}, codes.OK},
+	} {
+		resp, err := h.SetUserRole(ctx, tc.req)
+		if status.Code(err) != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+		if tc.want == codes.OK && resp.Data.Role != string(authz.RoleStaff) {
+			t.Errorf("%s: returned role %q", tc.name, resp.Data.Role)
+		}
+	}
+
+	if err := config.DB.First(&user, user.ID).Error; err != nil || user.Role != string(authz.RoleStaff) {
+		t.Fatalf("stored role %q, %v", user.Role, err)
+	}
+	for role, want := range map[authz.Role]authz.Decision{
+		authz.RoleAdmin:    authz.Allow,
+		authz.RoleStaff:    authz.Denied,
+		authz.RoleCustomer: authz.Denied,
+		authz.RoleService:  authz.Denied,
+	} {
+		if got := authz.Check(userProto.UserService_SetUserRole_FullMethodName, true, role); got != want {
+			t.Errorf("%s calling SetUserRole: got %v, want %v", role, got, want)
+		}
+	}
+}
+
+func TestListUsersQuery(t *testing.T) {
+	testenv.Setup(t)
+	for _, name := range []string{"ann*x", `ann"y`, "annz", "bob"} {
+		if err := config.DB.Create(&model.User{Username: name, Password: "x"}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := &UserHandler{}
+	ctx := context.Background()
+
+	for query, want := range map[string]string{
+		"ann*":   "ann*x",
+		`ann"`:   `ann"y`,
+		"ann":    `ann*x ann"y annz`,
+		"nobody": "",
+	} {
+		resp, err := h.ListUsers(ctx, &userProto.ListUsersRequest{Query: query})
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		var got []string
+		for _, u := range resp.Users {
+			got = append(got, u.Username)
+		}
+		if strings.Join(got, " ") != want {
+			t.Errorf("query %q: got %v, want %s", query, got, want)
+		}
+	}
+
+	resp, err := h.ListUsers(ctx, &userProto.ListUsersRequest{Query: "ann", PageSize: 1})
+	if err != nil || resp.NextPageToken == "" {
+		t.Fatalf("first page: %v, token %q", err, resp.GetNextPageToken())
+	}
+	_, err = h.ListUsers(ctx, &userProto.ListUsersRequest{Query: "bob", PageSize: 1, PageToken: resp.NextPageToken})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("token of another query: got %v, want InvalidArgument", err)
+	}
+}
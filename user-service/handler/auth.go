@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// issueTokens starts a new login session for user on the device deviceName.
+func issueTokens(ctx context.Context, user *model.User, deviceName string) (*auth.AccessToken, string, error) {
+	return auth.StartSession(ctx, user.ID, user.Role, auth.SessionInfo{
+		DeviceName: deviceName,
+		UserAgent:  userAgent(ctx),
+		IP:         clientIP(ctx),
+	})
+}
+
+// completeLogin finishes a login once the user passed the first factor. With
+// two-factor authentication on, only a challenge for LoginWithTOTP is
+// returned.
+func completeLogin(ctx context.Context, user *model.User, deviceName string) (*userProto.LoginResponse, error) {
+	if user.Disabled {
+		return &userProto.LoginResponse{Code: 403, Message: "Account is disabled"}, status.Error(codes.PermissionDenied, "account is disabled")
+	}
+	if user.TOTPEnabled {
+		challenge, err := auth.IssueChallenge(ctx, user.ID, deviceName)
+		if err != nil {
+			return &userProto.LoginResponse{Code: 500, Message: "Failed to start two-factor login"}, err
+		}
+		return &userProto.LoginResponse{
+			Code:              0,
+			Message:           "Two-factor authentication required",
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+			ExpiresIn:         int64(config.TwoFactorChallengeTTL.Seconds()),
+		}, nil
+	}
+
+	access, refreshToken, err := issueTokens(ctx, user, deviceName)
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to generate token"}, err
+	}
+
+	return &userProto.LoginResponse{
+		Code:         0,
+		Message:      "Success",
+		Token:        access.Token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(config.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (h *UserHandler) RefreshToken(ctx context.Context, req *userProto.RefreshTokenRequest) (*userProto.RefreshTokenResponse, error) {
+	userID, sessionID, refreshToken, err := auth.RotateRefreshToken(ctx, req.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidRefreshToken) || errors.Is(err, auth.ErrRefreshTokenReused) {
+		return &userProto.RefreshTokenResponse{Code: 401, Message: "Invalid refresh token"}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return &userProto.RefreshTokenResponse{Code: 500, Message: "Failed to refresh token"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.RefreshTokenResponse{Code: 401, Message: "Invalid refresh token"}, status.Error(codes.Unauthenticated, "user no longer exists")
+	}
+	if user.Disabled {
+		return &userProto.RefreshTokenResponse{Code: 403, Message: "Account is disabled"}, status.Error(codes.PermissionDenied, "account is disabled")
+	}
+
+	access, err := auth.ContinueSession(ctx, sessionID, user.ID, user.Role, clientIP(ctx))
+	if errors.Is(err, auth.ErrSessionNotFound) {
+		return &userProto.RefreshTokenResponse{Code: 401, Message: "Invalid refresh token"}, status.Error(codes.Unauthenticated, "session was revoked")
+	}
+	if err != nil {
+		return &userProto.RefreshTokenResponse{Code: 500, Message: "Failed to generate token"}, err
+	}
+
+	return &userProto.RefreshTokenResponse{
+		Code:         0,
+		Message:      "Success",
+		Token:        access.Token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(config.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (h *UserHandler) Logout(ctx context.Context, req *userProto.LogoutRequest) (*userProto.LogoutResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return &userProto.LogoutResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	if req.AllDevices {
+		if err := auth.SignOutEverywhere(ctx, uint(id.UserID)); err != nil {
+			return &userProto.LogoutResponse{Code: 500, Message: "Failed to revoke tokens"}, err
+		}
+		return &userProto.LogoutResponse{Code: 0, Message: "Success"}, nil
+	}
+
+	if id.SessionID != "" {
+		err := auth.RevokeSession(ctx, uint(id.UserID), id.SessionID)
+		if err != nil && !errors.Is(err, auth.ErrSessionNotFound) {
+			return &userProto.LogoutResponse{Code: 500, Message: "Failed to revoke session"}, err
+		}
+	}
+	if id.TokenID != "" {
+		if err := auth.RevokeAccessToken(ctx, &auth.AccessToken{ID: id.TokenID, ExpiresAt: id.ExpiresAt}); err != nil {
+			return &userProto.LogoutResponse{Code: 500, Message: "Failed to revoke token"}, err
+		}
+	}
+	if req.RefreshToken != "" {
+		if err := auth.RevokeRefreshToken(ctx, uint(id.UserID), req.RefreshToken); err != nil {
+			return &userProto.LogoutResponse{Code: 500, Message: "Failed to revoke token"}, err
+		}
+	}
+
+	return &userProto.LogoutResponse{Code: 0, Message: "Success"}, nil
+}
+
+func (h *UserHandler) GetJWKS(ctx context.Context, req *userProto.GetJWKSRequest) (*userProto.GetJWKSResponse, error) {
+	resp := &userProto.GetJWKSResponse{}
+	for _, key := range auth.PublicKeys() {
+		resp.Keys = append(resp.Keys, &userProto.JWK{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: "sig",
+			Alg: key.Alg,
+			Crv: key.Crv,
+			X:   key.X,
+			N:   key.N,
+			E:   key.E,
+		})
+	}
+	return resp, nil
+}
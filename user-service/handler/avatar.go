@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/avatar"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *UserHandler) UploadAvatar(ctx context.Context, req *userProto.UploadAvatarRequest) (*userProto.UploadAvatarResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.UploadAvatarResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+	if len(req.Image) == 0 {
+		return &userProto.UploadAvatarResponse{Code: 400, Message: "Image is required"}, status.Error(codes.InvalidArgument, "image is required")
+	}
+	if len(req.Image) > config.AvatarMaxBytes {
+		return &userProto.UploadAvatarResponse{Code: 413, Message: "Image is too large"},
+			status.Errorf(codes.InvalidArgument, "image must be at most %d bytes", config.AvatarMaxBytes)
+	}
+
+	images, err := avatar.Process(req.Image)
+	if errors.Is(err, avatar.ErrUnsupportedFormat) {
+		return &userProto.UploadAvatarResponse{Code: 415, Message: "Unsupported image format"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &userProto.UploadAvatarResponse{Code: 400, Message: "Invalid image"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.UploadAvatarResponse{Code: 404, Message: "User not found"}, err
+	}
+
+	// Every upload gets new keys, so caches never serve a stale picture.
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return &userProto.UploadAvatarResponse{Code: 500, Message: "Failed to store avatar"}, err
+	}
+	key := fmt.Sprintf("avatars/%d/%s", userID, hex.EncodeToString(suffix))
+	if err := h.Storage.Put(ctx, avatar.ImageKey(key), images.Avatar, "image/jpeg"); err != nil {
+		return &userProto.UploadAvatarResponse{Code: 500, Message: "Failed to store avatar"}, err
+	}
+	if err := h.Storage.Put(ctx, avatar.ThumbnailKey(key), images.Thumbnail, "image/jpeg"); err != nil {
+		return &userProto.UploadAvatarResponse{Code: 500, Message: "Failed to store avatar"}, err
+	}
+
+	oldKey := user.AvatarKey
+	user.AvatarKey = key
+	user.AvatarURL = h.Storage.URL(avatar.ImageKey(key))
+	user.AvatarThumbnailURL = h.Storage.URL(avatar.ThumbnailKey(key))
+	if err := config.DB.WithContext(ctx).Model(&user).Select("avatar_key", "avatar_url", "avatar_thumbnail_url").Updates(&user).Error; err != nil {
+		return &userProto.UploadAvatarResponse{Code: 500, Message: "Failed to update avatar"}, err
+	}
+	if oldKey != "" {
+		if err := avatar.Delete(ctx, h.Storage, oldKey); err != nil {
+			log.Printf("Failed to delete old avatar %s: %v", oldKey, err)
+		}
+	}
+
+	return &userProto.UploadAvatarResponse{
+		Code:    0,
+		Message: "Success",
+		Data:    userToProto(&user),
+	}, nil
+}
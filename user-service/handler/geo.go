@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/geo"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Suggestion counts of Autocomplete.
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = 20
+)
+
+func geoPlaceToProto(r *geo.Result) *userProto.GeoPlace {
+	return &userProto.GeoPlace{
+		Label:     r.Label,
+		Province:  r.Province,
+		City:      r.City,
+		District:  r.District,
+		Street:    r.Street,
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+		Precision: r.Precision,
+	}
+}
+
+func (h *UserHandler) Autocomplete(ctx context.Context, req *userProto.AutocompleteRequest) (*userProto.AutocompleteResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return &userProto.AutocompleteResponse{Code: 400, Message: "Query is required"}, status.Error(codes.InvalidArgument, "query is required")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultAutocompleteLimit
+	}
+	if limit > maxAutocompleteLimit {
+		limit = maxAutocompleteLimit
+	}
+
+	results, err := h.Geocoder.Autocomplete(ctx, query, limit)
+	if err != nil {
+		return &userProto.AutocompleteResponse{Code: 500, Message: "Failed to suggest places"}, err
+	}
+	resp := &userProto.AutocompleteResponse{Code: 0, Message: "Success"}
+	for i := range results {
+		resp.Suggestions = append(resp.Suggestions, geoPlaceToProto(&results[i]))
+	}
+	return resp, nil
+}
+
+func (h *UserHandler) ReverseGeocode(ctx context.Context, req *userProto.ReverseGeocodeRequest) (*userProto.ReverseGeocodeResponse, error) {
+	if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 ||
+		(req.Latitude == 0 && req.Longitude == 0) {
+		return &userProto.ReverseGeocodeResponse{Code: 400, Message: "Invalid coordinates"},
+			status.Error(codes.InvalidArgument, "latitude and longitude are required and must be in range")
+	}
+
+	result, err := h.Geocoder.Reverse(ctx, req.Latitude, req.Longitude)
+	if errors.Is(err, geo.ErrNotFound) {
+		return &userProto.ReverseGeocodeResponse{Code: 404, Message: "No place found"}, status.Error(codes.NotFound, "no place found at this location")
+	}
+	if err != nil {
+		return &userProto.ReverseGeocodeResponse{Code: 500, Message: "Failed to look up location"}, err
+	}
+	return &userProto.ReverseGeocodeResponse{Code: 0, Message: "Success", Place: geoPlaceToProto(result)}, nil
+}
+
+// geocodeAddress fills in the coordinates of an address that was saved
+// without them. Only a match on the place itself is precise enough to
+// decide delivery: the centre of a district or city may lie in another
+// store's zone than the address, so coarser matches are ignored. Failures
+// only leave the coordinates empty.
+func (h *UserHandler) geocodeAddress(ctx context.Context, address *model.Address) {
+	if address.Latitude != nil || h.Geocoder == nil {
+		return
+	}
+	result, err := h.Geocoder.Geocode(ctx, address.AddressDetail)
+	if errors.Is(err, geo.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to geocode address of user %d: %v", address.UserID, err)
+		return
+	}
+	if result.Precision != geo.PrecisionPlace {
+		return
+	}
+	address.Latitude, address.Longitude = &result.Latitude, &result.Longitude
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/geo"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+)
+
+func TestGeocodeAddressPrecision(t *testing.T) {
+	geocoder, err := geo.NewOffline("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &UserHandler{Geocoder: geocoder}
+
+	for detail, located := range map[string]bool{
+		"上海市黄浦区中山东一路外滩18号": true,
+		"上海市黄浦区某某路1号":      false,
+		"上海市某某路1号":         false,
+		"火星基地1号":           false,
+	} {
+		address := &model.Address{AddressDetail: detail}
+		h.geocodeAddress(context.Background(), address)
+		if (address.Latitude != nil) != located {
+			t.Errorf("%s: located %v, want %v", detail, address.Latitude != nil, located)
+		}
+	}
+
+	lat, lng := 1.0, 2.0
+	address := &model.Address{AddressDetail: "上海市黄浦区中山东一路外滩18号", Latitude: &lat, Longitude: &lng}
+	h.geocodeAddress(context.Background(), address)
+	if *address.Latitude != 1 || *address.Longitude != 2 {
+		t.Error("coordinates given by the client were replaced")
+	}
+}
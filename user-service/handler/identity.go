@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// caller returns the authenticated identity of the request.
+func caller(ctx context.Context) (*identity.Identity, error) {
+	id, ok := identity.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing caller identity")
+	}
+	return id, nil
+}
+
+// callerID returns the authenticated user making the request. A non-zero
+// requested user ID that does not match the caller is rejected, so clients
+// can no longer act on other users' data by passing user_id themselves.
+func callerID(ctx context.Context, requested uint32) (uint32, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if requested != 0 && requested != id.UserID {
+		return 0, status.Error(codes.PermissionDenied, "user_id does not match the authenticated user")
+	}
+	return id.UserID, nil
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/membership"
+)
+
+func membershipToProto(program *membership.Program, s *membership.Status) *userProto.Membership {
+	m := &userProto.Membership{
+		Tier:             s.Tier.Name,
+		Benefits:         s.Tier.Benefits,
+		LifetimePoints:   int32(s.LifetimePoints),
+		WindowDays:       int32(program.WindowDays),
+		PointsToNextTier: int32(s.PointsToNext()),
+		ProgressPercent:  int32(s.ProgressPercent()),
+	}
+	if s.Next != nil {
+		m.NextTier = s.Next.Name
+		m.NextTierThreshold = int32(s.Next.Threshold)
+	}
+	if s.TierSince != nil {
+		m.TierSince = s.TierSince.Unix()
+	}
+	if s.ReviewAt != nil {
+		m.DowngradeAt = s.ReviewAt.Unix()
+	}
+	return m
+}
+
+func (h *UserHandler) GetMembership(ctx context.Context, req *userProto.GetMembershipRequest) (*userProto.GetMembershipResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.GetMembershipResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	// Tier changes are applied when points are earned and by the review
+	// job; reading the membership must not lock or write the user row.
+	s, err := h.Membership.Status(ctx, uint(userID), time.Now())
+	if err != nil {
+		return &userProto.GetMembershipResponse{Code: 404, Message: "User not found"}, notFoundOr(err, "user not found")
+	}
+	return &userProto.GetMembershipResponse{
+		Code:       0,
+		Message:    "Success",
+		Membership: membershipToProto(h.Membership, s),
+	}, nil
+}
+
+// evaluateMembership applies tier upgrades right after points were earned;
+// the review job would only catch them on its next run.
+func (h *UserHandler) evaluateMembership(ctx context.Context, userID uint) {
+	if _, err := h.Membership.Evaluate(ctx, userID, time.Now()); err != nil {
+		log.Printf("Failed to evaluate membership of user %d: %v", userID, err)
+	}
+}
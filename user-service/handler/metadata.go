@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"net"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// clientIP returns the address of the end user. The api-gateway resolves it
+// from its trusted proxies and forwards it as x-client-ip; the
+// X-Forwarded-For added by grpc-gateway only names whatever is in front of
+// the gateway. The metadata is believed only from config.TrustedGateways,
+// other callers are identified by their peer address.
+func clientIP(ctx context.Context) string {
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+	}
+	if ip := net.ParseIP(addr); ip != nil && trustedGateway(ip) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if vals := md.Get(identity.MetadataClientIP); len(vals) == 1 && net.ParseIP(vals[0]) != nil {
+				return vals[0]
+			}
+		}
+	}
+	return addr
+}
+
+func trustedGateway(ip net.IP) bool {
+	for _, network := range config.TrustedGateways {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// userAgent returns the User-Agent of the end user. grpc-gateway forwards the
+// HTTP header as grpcgateway-user-agent; direct gRPC callers send their own.
+func userAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if vals := md.Get("grpcgateway-user-agent"); len(vals) > 0 {
+		return vals[0]
+	}
+	if vals := md.Get("user-agent"); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIP(t *testing.T) {
+	_, gateways, _ := net.ParseCIDR("10.0.0.0/8")
+	config.TrustedGateways = []*net.IPNet{gateways}
+	t.Cleanup(func() { config.TrustedGateways = nil })
+
+	for _, tt := range []struct {
+		name string
+		peer string
+		md   metadata.MD
+		want string
+	}{
+		{"gateway forwards client", "10.1.2.3:5000", metadata.Pairs(identity.MetadataClientIP, "203.0.113.9"), "203.0.113.9"},
+		{"gateway without metadata", "10.1.2.3:5000", nil, "10.1.2.3"},
+		{"untrusted caller", "198.51.100.4:5000", metadata.Pairs(identity.MetadataClientIP, "203.0.113.9"), "198.51.100.4"},
+		{"forwarded for is ignored", "10.1.2.3:5000", metadata.Pairs("x-forwarded-for", "203.0.113.9, 10.9.9.9"), "10.1.2.3"},
+		{"malformed metadata", "10.1.2.3:5000", metadata.Pairs(identity.MetadataClientIP, "not an ip"), "10.1.2.3"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := net.ResolveTCPAddr("tcp", tt.peer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			if got := clientIP(ctx); got != tt.want {
+				t.Errorf("clientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
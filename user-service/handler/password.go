@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (h *UserHandler) ChangePassword(ctx context.Context, req *userProto.ChangePasswordRequest) (*userProto.ChangePasswordResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.ChangePasswordResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.ChangePasswordResponse{Code: 404, Message: "User not found"}, err
+	}
+	if !utils.CheckPassword(req.OldPassword, user.Password) {
+		return &userProto.ChangePasswordResponse{Code: 400, Message: "Invalid password"}, status.Error(codes.InvalidArgument, "old password is incorrect")
+	}
+	if err := h.validatePassword(req.NewPassword); err != nil {
+		return &userProto.ChangePasswordResponse{Code: 400, Message: "Invalid new password"}, err
+	}
+
+	if err := setPassword(ctx, &user, req.NewPassword); err != nil {
+		return &userProto.ChangePasswordResponse{Code: 500, Message: "Failed to change password"}, err
+	}
+	return &userProto.ChangePasswordResponse{Code: 0, Message: "Success"}, nil
+}
+
+func (h *UserHandler) RequestPasswordReset(ctx context.Context, req *userProto.RequestPasswordResetRequest) (*userProto.RequestPasswordResetResponse, error) {
+	// The same answer is given whether or not the account exists.
+	resp := &userProto.RequestPasswordResetResponse{
+		Code:    0,
+		Message: "If the account exists, a reset code has been sent to its phone",
+	}
+
+	query := config.DB.WithContext(ctx)
+	switch {
+	case req.Username != "":
+		query = query.Where("username = ?", req.Username)
+	case req.Phone != "":
+		phone, ok := utils.NormalizePhone(req.Phone)
+		if !ok {
+			return &userProto.RequestPasswordResetResponse{Code: 400, Message: "Invalid phone number"}, status.Error(codes.InvalidArgument, "invalid phone number")
+		}
+		// Prefer the account that verified the phone.
+		query = query.Where("phone = ?", phone).Order("phone_verified DESC, id")
+	default:
+		return &userProto.RequestPasswordResetResponse{Code: 400, Message: "Username or phone is required"}, status.Error(codes.InvalidArgument, "username or phone is required")
+	}
+
+	var user model.User
+	err := query.First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.Phone == "") {
+		return resp, nil
+	}
+	if err != nil {
+		return &userProto.RequestPasswordResetResponse{Code: 500, Message: "Failed to request reset"}, err
+	}
+
+	token, err := auth.IssueResetToken(ctx, user.ID)
+	if errors.Is(err, auth.ErrResetCooldown) {
+		return resp, nil
+	}
+	if err != nil {
+		return &userProto.RequestPasswordResetResponse{Code: 500, Message: "Failed to request reset"}, err
+	}
+
+	message := fmt.Sprintf("Your Yixi Grocery password reset code is %s. It expires in %d minutes.", token, int(config.PasswordResetTTL.Minutes()))
+	if err := h.SMS.Send(ctx, user.Phone, message); err != nil {
+		return &userProto.RequestPasswordResetResponse{Code: 500, Message: "Failed to send reset code"}, err
+	}
+	return resp, nil
+}
+
+func (h *UserHandler) ConfirmPasswordReset(ctx context.Context, req *userProto.ConfirmPasswordResetRequest) (*userProto.ConfirmPasswordResetResponse, error) {
+	// Validate before the single-use token is consumed.
+	if err := h.validatePassword(req.NewPassword); err != nil {
+		return &userProto.ConfirmPasswordResetResponse{Code: 400, Message: "Invalid new password"}, err
+	}
+
+	userID, err := auth.ConsumeResetToken(ctx, req.Token)
+	if errors.Is(err, auth.ErrResetTokenInvalid) {
+		return &userProto.ConfirmPasswordResetResponse{Code: 400, Message: "Invalid or expired reset code"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &userProto.ConfirmPasswordResetResponse{Code: 500, Message: "Failed to reset password"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.ConfirmPasswordResetResponse{Code: 404, Message: "User not found"}, err
+	}
+
+	// The token was delivered to the phone, so its owner has proven it.
+	user.PhoneVerified = true
+	if err := setPassword(ctx, &user, req.NewPassword); err != nil {
+		return &userProto.ConfirmPasswordResetResponse{Code: 500, Message: "Failed to reset password"}, err
+	}
+	if err := auth.ClearLoginLockout(ctx, auth.SubjectUser, user.Username); err != nil {
+		return &userProto.ConfirmPasswordResetResponse{Code: 500, Message: "Failed to reset password"}, err
+	}
+	return &userProto.ConfirmPasswordResetResponse{Code: 0, Message: "Success"}, nil
+}
+
+// validatePassword checks a new password against the password policy
+// before it is stored.
+func (h *UserHandler) validatePassword(password string) error {
+	if err := h.PasswordPolicy.Check(password); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// setPassword stores a new password for user and signs out all of the
+// user's sessions.
+func setPassword(ctx context.Context, user *model.User, password string) error {
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+	if err := config.DB.WithContext(ctx).Model(user).Select("password", "phone_verified").Updates(user).Error; err != nil {
+		return err
+	}
+	return auth.SignOutEverywhere(ctx, user.ID)
+}
+
+// rehashPassword replaces the stored hash of user with one from the current
+// hasher. The login that revealed password succeeds even if this fails.
+func rehashPassword(ctx context.Context, user *model.User, password string) {
+	hashedPassword, err := utils.HashPassword(password)
+	if err == nil {
+		err = config.DB.WithContext(ctx).Model(&model.User{}).
+			Where("id = ? AND password = ?", user.ID, user.Password).
+			Update("password", hashedPassword).Error
+	}
+	if err != nil {
+		log.Printf("Failed to rehash password of user %d: %v", user.ID, err)
+		return
+	}
+	user.Password = hashedPassword
+}
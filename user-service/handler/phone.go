@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/referral"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (h *UserHandler) SendLoginCode(ctx context.Context, req *userProto.SendLoginCodeRequest) (*userProto.SendLoginCodeResponse, error) {
+	phone, ok := utils.NormalizePhone(req.Phone)
+	if !ok {
+		return &userProto.SendLoginCodeResponse{Code: 400, Message: "Invalid phone number"}, status.Error(codes.InvalidArgument, "invalid phone number")
+	}
+
+	code, err := auth.IssueCode(ctx, auth.PurposeLogin, phone)
+	var cooldown *auth.CooldownError
+	if errors.As(err, &cooldown) {
+		return &userProto.SendLoginCodeResponse{Code: 429, Message: "Code already sent"}, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		return &userProto.SendLoginCodeResponse{Code: 500, Message: "Failed to create code"}, err
+	}
+
+	message := fmt.Sprintf("Your Yixi Grocery login code is %s. It expires in %d minutes.", code, int(config.OTPCodeTTL.Minutes()))
+	if err := h.SMS.Send(ctx, phone, message); err != nil {
+		return &userProto.SendLoginCodeResponse{Code: 500, Message: "Failed to send code"}, err
+	}
+
+	return &userProto.SendLoginCodeResponse{
+		Code:        0,
+		Message:     "Success",
+		ExpiresIn:   int64(config.OTPCodeTTL.Seconds()),
+		ResendAfter: int64(config.OTPResendCooldown.Seconds()),
+	}, nil
+}
+
+func (h *UserHandler) LoginWithCode(ctx context.Context, req *userProto.LoginWithCodeRequest) (*userProto.LoginResponse, error) {
+	phone, ok := utils.NormalizePhone(req.Phone)
+	if !ok {
+		return &userProto.LoginResponse{Code: 400, Message: "Invalid phone number"}, status.Error(codes.InvalidArgument, "invalid phone number")
+	}
+
+	inviter, err := referralInviter(ctx, req.ReferralCode, req.DeviceId)
+	if err != nil {
+		return &userProto.LoginResponse{Code: 400, Message: "Invalid referral code"}, err
+	}
+
+	ip := clientIP(ctx)
+	wait, err := auth.CheckLoginLockout(ctx, auth.SubjectPhone, phone, ip)
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to check login attempts"}, err
+	}
+	if wait > 0 {
+		return &userProto.LoginResponse{Code: 429, Message: "Too many failed attempts"},
+			status.Errorf(codes.ResourceExhausted, "too many failed login attempts, retry in %d seconds", int(wait.Seconds())+1)
+	}
+
+	err = auth.VerifyCode(ctx, auth.PurposeLogin, phone, req.Code)
+	if errors.Is(err, auth.ErrCodeInvalid) || errors.Is(err, auth.ErrCodeAttemptsExceeded) {
+		if err := auth.RecordLoginFailure(ctx, auth.SubjectPhone, phone, ip); err != nil {
+			return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+		}
+		return &userProto.LoginResponse{Code: 401, Message: "Invalid or expired code"}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to verify code"}, err
+	}
+	if err := auth.RecordLoginSuccess(ctx, auth.SubjectPhone, phone); err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+	}
+
+	// Only accounts that proved ownership of the phone may be logged into
+	// with it; a number merely typed in at Register does not count.
+	var user model.User
+	registered := false
+	err = config.DB.WithContext(ctx).Where("phone = ? AND phone_verified = ?", phone, true).Order("id").First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		user, err = registerByPhone(ctx, phone, req.DeviceId, inviter)
+		registered = true
+	}
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+	}
+
+	resp, err := completeLogin(ctx, &user, req.DeviceName)
+	if err != nil {
+		return resp, err
+	}
+	resp.Registered = registered
+	return resp, nil
+}
+
+// registerByPhone creates an account for a verified phone. It gets a random
+// username and an unusable password until the user sets one.
+func registerByPhone(ctx context.Context, phone, deviceID string, inviter *model.User) (model.User, error) {
+	suffix := make([]byte, 6)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(suffix); err != nil {
+		return model.User{}, err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return model.User{}, err
+	}
+	hashedPassword, err := utils.HashPassword(hex.EncodeToString(secret))
+	if err != nil {
+		return model.User{}, err
+	}
+	referralCode, err := referral.NewCode()
+	if err != nil {
+		return model.User{}, err
+	}
+
+	user := model.User{
+		Username:       "user_" + hex.EncodeToString(suffix),
+		Password:       hashedPassword,
+		Phone:          phone,
+		PhoneVerified:  true,
+		ReferralCode:   &referralCode,
+		SignupDeviceID: deviceID,
+	}
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		if inviter != nil {
+			_, err := referral.Record(tx, inviter, &user)
+			return err
+		}
+		return nil
+	})
+	return user, err
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/sms"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSendLoginCodeThroughFileSink(t *testing.T) {
+	testenv.Setup(t)
+	config.OTPCodeTTL = 5 * time.Minute
+	config.OTPResendCooldown = time.Minute
+	config.OTPMaxAttempts = 3
+	config.OTPSecret = []byte("test secret")
+
+	sink := filepath.Join(t.TempDir(), "sms.log")
+	sender, err := sms.New("file", sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &UserHandler{SMS: sender}
+	ctx := context.Background()
+
+	resp, err := h.SendLoginCode(ctx, &userProto.SendLoginCodeRequest{Phone: "13800000000"})
+	if err != nil {
+		t.Fatalf("SendLoginCode: %v", err)
+	}
+	if resp.ExpiresIn != 300 || resp.ResendAfter != 60 {
+		t.Errorf("expires in %d, resend after %d", resp.ExpiresIn, resp.ResendAfter)
+	}
+
+	data, err := os.ReadFile(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := regexp.MustCompile(`\t(\+?[0-9]+)\t.*code is ([0-9]{6})`).FindStringSubmatch(string(data))
+	if m == nil {
+		t.Fatalf("no code in sink: %q", data)
+	}
+	phone, code := m[1], m[2]
+
+	_, err = h.SendLoginCode(ctx, &userProto.SendLoginCodeRequest{Phone: "13800000000"})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("resend during cooldown: got %v, want ResourceExhausted", err)
+	}
+
+	if err := auth.VerifyCode(ctx, auth.PurposeLogin, phone, code); err != nil {
+		t.Fatalf("code from sink rejected: %v", err)
+	}
+	if err := auth.VerifyCode(ctx, auth.PurposeLogin, phone, code); err == nil {
+		t.Fatal("code from sink accepted twice")
+	}
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/paging"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/points"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxReferenceIDLength = 64
+
+var pointsHistoryList = &paging.Spec{
+	Fields: map[string]paging.Field{
+		"id":           {Column: "id", Kind: paging.Int, Sortable: true},
+		"type":         {Column: "type", Kind: paging.String, Sortable: true},
+		"delta":        {Column: "delta", Kind: paging.Int, Sortable: true},
+		"reason":       {Column: "reason", Kind: paging.String},
+		"reference_id": {Column: "reference_id", Kind: paging.String},
+		"created_at":   {Column: "created_at", Kind: paging.Time, Sortable: true},
+	},
+	Key:             "id",
+	DefaultOrder:    "id desc",
+	DefaultPageSize: 20,
+	MaxPageSize:     100,
+}
+
+func pointsTransactionToProto(entry *model.PointsTransaction) *userProto.PointsTransaction {
+	tx := &userProto.PointsTransaction{
+		Id:           uint32(entry.ID),
+		Type:         entry.Type,
+		Delta:        int32(entry.Delta),
+		Reason:       entry.Reason,
+		BalanceAfter: int32(entry.BalanceAfter),
+		CreatedAt:    entry.CreatedAt.Unix(),
+	}
+	if entry.ReferenceID != nil {
+		tx.ReferenceId = *entry.ReferenceID
+	}
+	return tx
+}
+
+// pointsError maps the errors of points.Apply to statuses.
+func pointsError(err error) error {
+	switch {
+	case errors.Is(err, points.ErrInsufficientPoints):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, points.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, points.ErrReferenceConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return err
+}
+
+// validatePointsChange checks the amount and reference ID of an earn or
+// redeem request.
+func validatePointsChange(amount int32, referenceID string) error {
+	if amount <= 0 {
+		return status.Error(codes.InvalidArgument, "amount must be greater than zero")
+	}
+	if strings.TrimSpace(referenceID) == "" {
+		return status.Error(codes.InvalidArgument, "reference_id is required")
+	}
+	if len(referenceID) > maxReferenceIDLength {
+		return status.Errorf(codes.InvalidArgument, "reference_id must be at most %d characters", maxReferenceIDLength)
+	}
+	return nil
+}
+
+func (h *UserHandler) EarnPoints(ctx context.Context, req *userProto.EarnPointsRequest) (*userProto.EarnPointsResponse, error) {
+	if err := validatePointsChange(req.Amount, req.ReferenceId); err != nil {
+		return &userProto.EarnPointsResponse{Code: 400, Message: "Invalid request"}, err
+	}
+
+	entry, replayed, err := points.Apply(ctx, points.Change{
+		UserID:      uint(req.UserId),
+		Type:        model.PointsEarn,
+		Delta:       int(req.Amount),
+		ReferenceID: req.ReferenceId,
+		Reason:      req.Reason,
+	})
+	switch {
+	case errors.Is(err, points.ErrUserNotFound):
+		return &userProto.EarnPointsResponse{Code: 404, Message: "User not found"}, pointsError(err)
+	case errors.Is(err, points.ErrReferenceConflict):
+		return &userProto.EarnPointsResponse{Code: 409, Message: "Reference ID already used"}, pointsError(err)
+	case err != nil:
+		return &userProto.EarnPointsResponse{Code: 500, Message: "Failed to earn points"}, pointsError(err)
+	}
+	if !replayed {
+		h.evaluateMembership(ctx, entry.UserID)
+	}
+
+	balance, err := pointsBalance(ctx, entry.UserID)
+	if err != nil {
+		return &userProto.EarnPointsResponse{Code: 500, Message: "Failed to earn points"}, err
+	}
+	message := "Success"
+	if replayed {
+		message = "Already applied"
+	}
+	return &userProto.EarnPointsResponse{
+		Code:        0,
+		Message:     message,
+		Transaction: pointsTransactionToProto(entry),
+		Balance:     int32(balance),
+	}, nil
+}
+
+func (h *UserHandler) RedeemPoints(ctx context.Context, req *userProto.RedeemPointsRequest) (*userProto.RedeemPointsResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.RedeemPointsResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+	if err := validatePointsChange(req.Amount, req.ReferenceId); err != nil {
+		return &userProto.RedeemPointsResponse{Code: 400, Message: "Invalid request"}, err
+	}
+
+	entry, replayed, err := points.Apply(ctx, points.Change{
+		UserID:      uint(userID),
+		Type:        model.PointsRedeem,
+		Delta:       -int(req.Amount),
+		ReferenceID: req.ReferenceId,
+		Reason:      req.Reason,
+	})
+	switch {
+	case errors.Is(err, points.ErrInsufficientPoints):
+		return &userProto.RedeemPointsResponse{Code: 400, Message: "Insufficient points"}, pointsError(err)
+	case errors.Is(err, points.ErrReferenceConflict):
+		return &userProto.RedeemPointsResponse{Code: 409, Message: "Reference ID already used"}, pointsError(err)
+	case err != nil:
+		return &userProto.RedeemPointsResponse{Code: 500, Message: "Failed to redeem points"}, pointsError(err)
+	}
+
+	balance, err := pointsBalance(ctx, entry.UserID)
+	if err != nil {
+		return &userProto.RedeemPointsResponse{Code: 500, Message: "Failed to redeem points"}, err
+	}
+	message := "Success"
+	if replayed {
+		message = "Already applied"
+	}
+	return &userProto.RedeemPointsResponse{
+		Code:        0,
+		Message:     message,
+		Transaction: pointsTransactionToProto(entry),
+		Balance:     int32(balance),
+	}, nil
+}
+
+func (h *UserHandler) ListPointsHistory(ctx context.Context, req *userProto.ListPointsHistoryRequest) (*userProto.ListPointsHistoryResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.ListPointsHistoryResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	query := config.DB.WithContext(ctx).Where("user_id = ?", userID)
+	entries, next, err := paging.Find[model.PointsTransaction](query, pointsHistoryList, paging.Request{
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		Filter:    req.Filter,
+		OrderBy:   req.OrderBy,
+	})
+	if errors.Is(err, paging.ErrInvalid) {
+		return &userProto.ListPointsHistoryResponse{Code: 400, Message: "Invalid list request"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &userProto.ListPointsHistoryResponse{Code: 500, Message: "Failed to list points history"}, err
+	}
+	balance, err := pointsBalance(ctx, uint(userID))
+	if err != nil {
+		return &userProto.ListPointsHistoryResponse{Code: 500, Message: "Failed to list points history"}, err
+	}
+
+	resp := &userProto.ListPointsHistoryResponse{Code: 0, Message: "Success", Balance: int32(balance), NextPageToken: next}
+	for i := range entries {
+		resp.Transactions = append(resp.Transactions, pointsTransactionToProto(&entries[i]))
+	}
+	return resp, nil
+}
+
+func pointsBalance(ctx context.Context, userID uint) (int, error) {
+	var balance int
+	err := config.DB.WithContext(ctx).Model(&model.User{}).Where("id = ?", userID).Pluck("points", &balance).Error
+	return balance, err
+}
+
+const maxExpiringWithinDays = 365
+
+func (h *UserHandler) GetExpiringPoints(ctx context.Context, req *userProto.GetExpiringPointsRequest) (*userProto.GetExpiringPointsResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.GetExpiringPointsResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+	if req.WithinDays < 0 || req.WithinDays > maxExpiringWithinDays {
+		return &userProto.GetExpiringPointsResponse{Code: 400, Message: "Invalid within_days"},
+			status.Errorf(codes.InvalidArgument, "within_days must be between 0 and %d", maxExpiringWithinDays)
+	}
+	window := config.PointsExpiringSoonWindow
+	if req.WithinDays > 0 {
+		window = time.Duration(req.WithinDays) * 24 * time.Hour
+	}
+
+	lots, err := points.Expiring(ctx, uint(userID), time.Now().Add(window))
+	if err != nil {
+		return &userProto.GetExpiringPointsResponse{Code: 500, Message: "Failed to list expiring points"}, err
+	}
+	resp := &userProto.GetExpiringPointsResponse{Code: 0, Message: "Success"}
+	for _, lot := range lots {
+		resp.Total += int32(lot.Remaining)
+		resp.Expiring = append(resp.Expiring, &userProto.ExpiringPoints{
+			Amount:    int32(lot.Remaining),
+			ExpiresAt: lot.ExpiresAt.Unix(),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// updatableUserFields are the User fields UpdateUserInfo may change.
+var updatableUserFields = []string{"phone", "address", "nickname", "email", "gender", "birthday"}
+
+func (h *UserHandler) UpdateUserInfo(ctx context.Context, req *userProto.UpdateUserInfoRequest) (*userProto.UpdateUserInfoResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.UpdateUserInfoResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+	if req.User == nil {
+		return &userProto.UpdateUserInfoResponse{Code: 400, Message: "User is required"}, status.Error(codes.InvalidArgument, "user is required")
+	}
+
+	paths, err := updatePaths(req.UpdateMask, req.User)
+	if err != nil {
+		return &userProto.UpdateUserInfoResponse{Code: 400, Message: "Invalid update mask"}, err
+	}
+	updates, err := profileUpdates(paths, req.User)
+	if err != nil {
+		return &userProto.UpdateUserInfoResponse{Code: 400, Message: "Invalid user info"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.UpdateUserInfoResponse{Code: 404, Message: "User not found"}, err
+	}
+	if phone, ok := updates["phone"]; ok && phone != user.Phone {
+		// The new number has not been proven to belong to the user.
+		updates["phone_verified"] = false
+	}
+	if len(updates) > 0 {
+		if err := config.DB.WithContext(ctx).Model(&user).Updates(updates).Error; err != nil {
+			return &userProto.UpdateUserInfoResponse{Code: 500, Message: "Failed to update user info"}, err
+		}
+	}
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.UpdateUserInfoResponse{Code: 500, Message: "Failed to update user info"}, err
+	}
+
+	return &userProto.UpdateUserInfoResponse{
+		Code:    0,
+		Message: "Success",
+		Data:    userToProto(&user),
+	}, nil
+}
+
+// updatePaths returns the fields to update. Without a mask, every updatable
+// field set in user is updated.
+func updatePaths(mask *fieldmaskpb.FieldMask, user *userProto.User) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		var paths []string
+		msg := user.ProtoReflect()
+		for _, path := range updatableUserFields {
+			if msg.Has(msg.Descriptor().Fields().ByName(protoreflect.Name(path))) {
+				paths = append(paths, path)
+			}
+		}
+		return paths, nil
+	}
+
+	if !mask.IsValid(user) {
+		return nil, status.Error(codes.InvalidArgument, "update_mask names unknown fields")
+	}
+	mask.Normalize()
+	for _, path := range mask.GetPaths() {
+		if !slices.Contains(updatableUserFields, path) {
+			return nil, status.Errorf(codes.InvalidArgument, "field %s cannot be updated", path)
+		}
+	}
+	return mask.GetPaths(), nil
+}
+
+// profileUpdates validates the new values of paths and returns them as
+// column updates. Empty values clear a field.
+func profileUpdates(paths []string, user *userProto.User) (map[string]interface{}, error) {
+	updates := map[string]interface{}{}
+	for _, path := range paths {
+		switch path {
+		case "phone":
+			if user.Phone == "" {
+				updates["phone"] = ""
+				continue
+			}
+			phone, ok := utils.NormalizePhone(user.Phone)
+			if !ok {
+				return nil, status.Error(codes.InvalidArgument, "invalid phone number")
+			}
+			updates["phone"] = phone
+		case "address":
+			address := strings.TrimSpace(user.Address)
+			if err := utils.ValidateAddress(address); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			updates["address"] = address
+		case "nickname":
+			nickname := strings.TrimSpace(user.Nickname)
+			if err := utils.ValidateNickname(nickname); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			updates["nickname"] = nickname
+		case "email":
+			if user.Email == "" {
+				updates["email"] = ""
+				continue
+			}
+			email, ok := utils.NormalizeEmail(user.Email)
+			if !ok {
+				return nil, status.Error(codes.InvalidArgument, "invalid email address")
+			}
+			updates["email"] = email
+		case "gender":
+			if !utils.ValidGender(user.Gender) {
+				return nil, status.Errorf(codes.InvalidArgument, "gender must be one of %s", strings.Join(utils.Genders, ", "))
+			}
+			updates["gender"] = user.Gender
+		case "birthday":
+			if user.Birthday == "" {
+				updates["birthday"] = nil
+				continue
+			}
+			birthday, err := utils.ParseBirthday(user.Birthday)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			updates["birthday"] = birthday
+		}
+	}
+	return updates, nil
+}
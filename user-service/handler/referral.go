@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/paging"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/referral"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxDeviceIDLength = 64
+
+// referralInviter validates the sign-up fields of a referral and returns
+// the inviter, or nil when no code was given.
+func referralInviter(ctx context.Context, code, deviceID string) (*model.User, error) {
+	if len(deviceID) > maxDeviceIDLength {
+		return nil, status.Errorf(codes.InvalidArgument, "device_id must be at most %d characters", maxDeviceIDLength)
+	}
+	if code == "" {
+		return nil, nil
+	}
+	inviter, err := referral.FindInviter(ctx, code)
+	if errors.Is(err, referral.ErrInvalidCode) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return inviter, err
+}
+
+func referralToProto(ref *model.Referral) *userProto.Referral {
+	r := &userProto.Referral{
+		Id:        uint32(ref.ID),
+		Status:    ref.Status,
+		CreatedAt: ref.CreatedAt.Unix(),
+	}
+	if ref.RewardedAt != nil {
+		r.RewardedAt = ref.RewardedAt.Unix()
+	}
+	return r
+}
+
+var referralsList = &paging.Spec{
+	Fields: map[string]paging.Field{
+		"id":          {Column: "id", Kind: paging.Int, Sortable: true},
+		"status":      {Column: "status", Kind: paging.String, Sortable: true},
+		"created_at":  {Column: "created_at", Kind: paging.Time, Sortable: true},
+		"rewarded_at": {Column: "rewarded_at", Kind: paging.Time},
+	},
+	Key:             "id",
+	DefaultOrder:    "id desc",
+	DefaultPageSize: 20,
+	MaxPageSize:     100,
+}
+
+func (h *UserHandler) GetReferrals(ctx context.Context, req *userProto.GetReferralsRequest) (*userProto.GetReferralsResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.GetReferralsResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	code, err := referral.EnsureCode(ctx, uint(userID))
+	if err != nil {
+		return &userProto.GetReferralsResponse{Code: 500, Message: "Failed to get referral code"}, notFoundOr(err, "user not found")
+	}
+	query := config.DB.WithContext(ctx).Where("inviter_id = ?", userID)
+	referrals, next, err := paging.Find[model.Referral](query, referralsList, paging.Request{
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		Filter:    req.Filter,
+		OrderBy:   req.OrderBy,
+	})
+	if errors.Is(err, paging.ErrInvalid) {
+		return &userProto.GetReferralsResponse{Code: 400, Message: "Invalid list request"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &userProto.GetReferralsResponse{Code: 500, Message: "Failed to list referrals"}, err
+	}
+
+	resp := &userProto.GetReferralsResponse{
+		Code:          0,
+		Message:       "Success",
+		ReferralCode:  code,
+		InviterPoints: int32(config.ReferralInviterPoints),
+		InviteePoints: int32(config.ReferralInviteePoints),
+		NextPageToken: next,
+	}
+	for i := range referrals {
+		resp.Referrals = append(resp.Referrals, referralToProto(&referrals[i]))
+	}
+	return resp, nil
+}
+
+func (h *UserHandler) CompleteReferral(ctx context.Context, req *userProto.CompleteReferralRequest) (*userProto.CompleteReferralResponse, error) {
+	if req.UserId == 0 || req.OrderId == "" {
+		return &userProto.CompleteReferralResponse{Code: 400, Message: "user_id and order_id are required"},
+			status.Error(codes.InvalidArgument, "user_id and order_id are required")
+	}
+	if len(req.OrderId) > maxReferenceIDLength {
+		return &userProto.CompleteReferralResponse{Code: 400, Message: "Invalid order ID"},
+			status.Errorf(codes.InvalidArgument, "order_id must be at most %d characters", maxReferenceIDLength)
+	}
+
+	ref, err := referral.Complete(ctx, uint(req.UserId), req.OrderId)
+	if errors.Is(err, referral.ErrNotReferred) {
+		return &userProto.CompleteReferralResponse{Code: 404, Message: "User was not referred"}, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return &userProto.CompleteReferralResponse{Code: 500, Message: "Failed to complete referral"}, err
+	}
+	if ref.Status == model.ReferralRewarded {
+		for _, id := range []uint{ref.InviterID, ref.InviteeID} {
+			h.evaluateMembership(ctx, id)
+		}
+	}
+	return &userProto.CompleteReferralResponse{Code: 0, Message: "Success", Referral: referralToProto(ref)}, nil
+}
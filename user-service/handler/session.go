@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/paging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sessionsList does not sort by last_seen_at: TouchSession moves it on every
+// request, so sessions would cross the page cursor between pages.
+var sessionsList = &paging.Spec{
+	Fields: map[string]paging.Field{
+		"id":           {Column: "id", Kind: paging.String, Sortable: true},
+		"device_name":  {Column: "device_name", Kind: paging.String, Sortable: true},
+		"user_agent":   {Column: "user_agent", Kind: paging.String},
+		"ip":           {Column: "ip", Kind: paging.String},
+		"created_at":   {Column: "created_at", Kind: paging.Time, Sortable: true},
+		"last_seen_at": {Column: "last_seen_at", Kind: paging.Time},
+	},
+	Key:             "id",
+	DefaultOrder:    "created_at desc",
+	DefaultPageSize: 50,
+	MaxPageSize:     100,
+}
+
+func (h *UserHandler) ListSessions(ctx context.Context, req *userProto.ListSessionsRequest) (*userProto.ListSessionsResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return &userProto.ListSessionsResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	sessions, next, err := paging.Find[model.Session](auth.ActiveSessions(ctx, uint(id.UserID)), sessionsList, paging.Request{
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		Filter:    req.Filter,
+		OrderBy:   req.OrderBy,
+	})
+	if errors.Is(err, paging.ErrInvalid) {
+		return &userProto.ListSessionsResponse{Code: 400, Message: "Invalid list request"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &userProto.ListSessionsResponse{Code: 500, Message: "Failed to list sessions"}, err
+	}
+
+	resp := &userProto.ListSessionsResponse{Code: 0, Message: "Success", NextPageToken: next}
+	for _, s := range sessions {
+		resp.Sessions = append(resp.Sessions, &userProto.Session{
+			Id:         s.ID,
+			DeviceName: s.DeviceName,
+			UserAgent:  s.UserAgent,
+			Ip:         s.IP,
+			CreatedAt:  s.CreatedAt.Unix(),
+			LastSeenAt: s.LastSeenAt.Unix(),
+			Current:    s.ID == id.SessionID,
+		})
+	}
+	return resp, nil
+}
+
+func (h *UserHandler) RevokeSession(ctx context.Context, req *userProto.RevokeSessionRequest) (*userProto.RevokeSessionResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return &userProto.RevokeSessionResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	err = auth.RevokeSession(ctx, uint(id.UserID), req.Id)
+	if errors.Is(err, auth.ErrSessionNotFound) {
+		return &userProto.RevokeSessionResponse{Code: 404, Message: "Session not found"}, status.Error(codes.NotFound, "session not found")
+	}
+	if err != nil {
+		return &userProto.RevokeSessionResponse{Code: 500, Message: "Failed to revoke session"}, err
+	}
+	return &userProto.RevokeSessionResponse{Code: 0, Message: "Success"}, nil
+}
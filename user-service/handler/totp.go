@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// recoveryCodeCount is how many recovery codes VerifyTOTP hands out.
+const recoveryCodeCount = 10
+
+func (h *UserHandler) EnableTOTP(ctx context.Context, req *userProto.EnableTOTPRequest) (*userProto.EnableTOTPResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.EnableTOTPResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+	if config.TOTPEncryptionKey == nil {
+		return &userProto.EnableTOTPResponse{Code: 503, Message: "Two-factor authentication is not available"}, status.Error(codes.FailedPrecondition, "TOTP_ENCRYPTION_KEY is not configured")
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.EnableTOTPResponse{Code: 404, Message: "User not found"}, err
+	}
+	if !utils.CheckPassword(req.Password, user.Password) {
+		return &userProto.EnableTOTPResponse{Code: 400, Message: "Invalid password"}, status.Error(codes.InvalidArgument, "password is incorrect")
+	}
+	if user.TOTPEnabled {
+		return &userProto.EnableTOTPResponse{Code: 409, Message: "Two-factor authentication is already enabled"}, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return &userProto.EnableTOTPResponse{Code: 500, Message: "Failed to generate secret"}, err
+	}
+	encrypted, err := utils.EncryptSecret(config.TOTPEncryptionKey, secret)
+	if err != nil {
+		return &userProto.EnableTOTPResponse{Code: 500, Message: "Failed to store secret"}, err
+	}
+	if err := config.DB.WithContext(ctx).Model(&user).Update("totp_secret", encrypted).Error; err != nil {
+		return &userProto.EnableTOTPResponse{Code: 500, Message: "Failed to store secret"}, err
+	}
+
+	return &userProto.EnableTOTPResponse{
+		Code:       0,
+		Message:    "Success",
+		Secret:     secret,
+		OtpauthUrl: utils.TOTPURL(config.TOTPIssuer, user.Username, secret),
+	}, nil
+}
+
+func (h *UserHandler) VerifyTOTP(ctx context.Context, req *userProto.VerifyTOTPRequest) (*userProto.VerifyTOTPResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.VerifyTOTPResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.VerifyTOTPResponse{Code: 404, Message: "User not found"}, err
+	}
+	if user.TOTPEnabled || user.TOTPSecret == "" {
+		return &userProto.VerifyTOTPResponse{Code: 409, Message: "No pending two-factor setup"}, status.Error(codes.FailedPrecondition, "call EnableTOTP first")
+	}
+	ok, err := checkTOTP(ctx, &user, req.Code)
+	if err != nil {
+		return &userProto.VerifyTOTPResponse{Code: 500, Message: "Failed to verify code"}, err
+	}
+	if !ok {
+		return &userProto.VerifyTOTPResponse{Code: 400, Message: "Invalid code"}, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return &userProto.VerifyTOTPResponse{Code: 500, Message: "Failed to generate recovery codes"}, err
+	}
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", user.ID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		rows := make([]model.RecoveryCode, len(hashes))
+		for i, hash := range hashes {
+			rows[i] = model.RecoveryCode{UserID: user.ID, CodeHash: hash}
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+		return tx.Model(&user).Update("totp_enabled", true).Error
+	})
+	if err != nil {
+		return &userProto.VerifyTOTPResponse{Code: 500, Message: "Failed to enable two-factor authentication"}, err
+	}
+
+	return &userProto.VerifyTOTPResponse{Code: 0, Message: "Success", RecoveryCodes: recoveryCodes}, nil
+}
+
+func (h *UserHandler) DisableTOTP(ctx context.Context, req *userProto.DisableTOTPRequest) (*userProto.DisableTOTPResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.DisableTOTPResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.DisableTOTPResponse{Code: 404, Message: "User not found"}, err
+	}
+	if !user.TOTPEnabled {
+		return &userProto.DisableTOTPResponse{Code: 409, Message: "Two-factor authentication is not enabled"}, status.Error(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+	if !utils.CheckPassword(req.Password, user.Password) {
+		return &userProto.DisableTOTPResponse{Code: 400, Message: "Invalid password"}, status.Error(codes.InvalidArgument, "password is incorrect")
+	}
+	ok, err := checkSecondFactor(ctx, &user, req.Code)
+	if err != nil {
+		return &userProto.DisableTOTPResponse{Code: 500, Message: "Failed to verify code"}, err
+	}
+	if !ok {
+		return &userProto.DisableTOTPResponse{Code: 400, Message: "Invalid code"}, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", user.ID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&user).Select("totp_secret", "totp_enabled").Updates(map[string]interface{}{
+			"totp_secret":  "",
+			"totp_enabled": false,
+		}).Error
+	})
+	if err != nil {
+		return &userProto.DisableTOTPResponse{Code: 500, Message: "Failed to disable two-factor authentication"}, err
+	}
+	return &userProto.DisableTOTPResponse{Code: 0, Message: "Success"}, nil
+}
+
+func (h *UserHandler) LoginWithTOTP(ctx context.Context, req *userProto.LoginWithTOTPRequest) (*userProto.LoginResponse, error) {
+	userID, deviceName, err := auth.ChallengeUser(ctx, req.ChallengeToken)
+	if errors.Is(err, auth.ErrChallengeInvalid) {
+		return &userProto.LoginResponse{Code: 401, Message: "Invalid or expired login challenge"}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+	}
+
+	// Wrong codes count towards the same lockout as wrong passwords, so a
+	// stolen password cannot be used to try codes with fresh challenges.
+	ip := clientIP(ctx)
+	wait, err := auth.CheckLoginLockout(ctx, auth.SubjectUser, user.Username, ip)
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to check login attempts"}, err
+	}
+	if wait > 0 {
+		return &userProto.LoginResponse{Code: 429, Message: "Too many failed attempts"},
+			status.Errorf(codes.ResourceExhausted, "too many failed login attempts, retry in %d seconds", int(wait.Seconds())+1)
+	}
+	ok, err := checkSecondFactor(ctx, &user, req.Code)
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to verify code"}, err
+	}
+	if !ok {
+		if err := auth.RecordLoginFailure(ctx, auth.SubjectUser, user.Username, ip); err != nil {
+			return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+		}
+		return &userProto.LoginResponse{Code: 401, Message: "Invalid code"}, status.Error(codes.Unauthenticated, "invalid code")
+	}
+	if err := auth.RecordLoginSuccess(ctx, auth.SubjectUser, user.Username); err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+	}
+	if err := auth.CompleteChallenge(ctx, req.ChallengeToken); err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+	}
+
+	if user.Disabled {
+		return &userProto.LoginResponse{Code: 403, Message: "Account is disabled"}, status.Error(codes.PermissionDenied, "account is disabled")
+	}
+	access, refreshToken, err := issueTokens(ctx, &user, deviceName)
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to generate token"}, err
+	}
+	return &userProto.LoginResponse{
+		Code:         0,
+		Message:      "Success",
+		Token:        access.Token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(config.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// checkSecondFactor accepts either a current TOTP code or an unused recovery
+// code, which is then spent.
+func checkSecondFactor(ctx context.Context, user *model.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if ok, err := checkTOTP(ctx, user, code); ok || err != nil {
+		return ok, err
+	}
+
+	var recoveryCodes []model.RecoveryCode
+	if err := config.DB.WithContext(ctx).Where("user_id = ? AND used_at IS NULL", user.ID).Find(&recoveryCodes).Error; err != nil {
+		return false, err
+	}
+	for _, rc := range recoveryCodes {
+		if !utils.CheckPassword(normalizeRecoveryCode(code), rc.CodeHash) {
+			continue
+		}
+		// The used_at condition makes concurrent use of one code fail.
+		res := config.DB.WithContext(ctx).Model(&model.RecoveryCode{}).
+			Where("id = ? AND used_at IS NULL", rc.ID).Update("used_at", time.Now())
+		if res.Error != nil {
+			return false, res.Error
+		}
+		return res.RowsAffected == 1, nil
+	}
+	return false, nil
+}
+
+// checkTOTP validates code against the user's TOTP secret. Each code is
+// accepted only once.
+func checkTOTP(ctx context.Context, user *model.User, code string) (bool, error) {
+	if user.TOTPSecret == "" || config.TOTPEncryptionKey == nil {
+		return false, nil
+	}
+	secret, err := utils.DecryptSecret(config.TOTPEncryptionKey, user.TOTPSecret)
+	if err != nil {
+		return false, err
+	}
+	step, ok := utils.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	return auth.MarkTOTPStepUsed(ctx, user.ID, step)
+}
+
+// recoveryCodeAlphabet avoids characters that are easily confused.
+const recoveryCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// generateRecoveryCodes returns new recovery codes, formatted XXXXX-XXXXX,
+// and their password hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	plain := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range plain {
+		raw := make([]byte, 10)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		for j, b := range raw {
+			raw[j] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+		}
+		plain[i] = string(raw[:5]) + "-" + string(raw[5:])
+		hash, err := utils.HashPassword(normalizeRecoveryCode(plain[i]))
+		if err != nil {
+			return nil, nil, err
+		}
+		hashes[i] = hash
+	}
+	return plain, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
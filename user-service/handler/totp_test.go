@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginWithTOTPLocksOut(t *testing.T) {
+	testenv.Setup(t)
+	config.LoginMaxFailuresPerUser = 3
+	config.LoginMaxFailuresPerIP = 100
+	config.LoginFailureWindow = 15 * time.Minute
+	config.LoginLockoutBase = time.Minute
+	config.LoginLockoutMax = time.Hour
+	config.TwoFactorChallengeTTL = 5 * time.Minute
+	config.TOTPEncryptionKey = make([]byte, 32)
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := utils.EncryptSecret(config.TOTPEncryptionKey, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := model.User{Username: "alice", Password: "x", TOTPSecret: encrypted, TOTPEnabled: true}
+	if err := config.DB.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	h := &UserHandler{}
+	ctx := context.Background()
+	// Every attempt uses a fresh challenge, as after another password login.
+	login := func() error {
+		challenge, err := auth.IssueChallenge(ctx, user.ID, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = h.LoginWithTOTP(ctx, &userProto.LoginWithTOTPRequest{ChallengeToken: challenge, Code: "not-a-code"})
+		return err
+	}
+	for i := 0; i < config.LoginMaxFailuresPerUser; i++ {
+		if err := login(); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("attempt %d: got %v, want Unauthenticated", i, err)
+		}
+	}
+	if err := login(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("after %d wrong codes: got %v, want ResourceExhausted", config.LoginMaxFailuresPerUser, err)
+	}
+	if wait, err := auth.CheckLoginLockout(ctx, auth.SubjectUser, "alice", ""); err != nil || wait <= 0 {
+		t.Fatalf("password login not locked out: wait %v, %v", wait, err)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	testenv.Setup(t)
+	ctx := context.Background()
+
+	user := model.User{Username: "alice", Password: "x", TOTPEnabled: true}
+	if err := config.DB.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	plain, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []model.RecoveryCode
+	for _, hash := range hashes {
+		rows = append(rows, model.RecoveryCode{UserID: user.ID, CodeHash: hash})
+	}
+	if err := config.DB.Create(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		code string
+		want bool
+	}{
+		{"as shown", plain[0], true},
+		{"reused", plain[0], false},
+		{"lower case without dash", " " + strings.ToLower(strings.ReplaceAll(plain[1], "-", "")) + " ", true},
+		{"wrong", "AAAAA-AAAAA", false},
+	} {
+		ok, err := checkSecondFactor(ctx, &user, tc.code)
+		if err != nil || ok != tc.want {
+			t.Errorf("%s: got %v, %v, want %v", tc.name, ok, err, tc.want)
+		}
+	}
+
+	other := model.User{Username: "bob", Password: "x", TOTPEnabled: true}
+	if err := config.DB.Create(&other).Error; err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := checkSecondFactor(ctx, &other, plain[2]); ok {
+		t.Error("code of another user accepted")
+	}
+}
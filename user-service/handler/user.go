@@ -2,156 +2,384 @@ package handler
 
 import (
 	"context"
-	"github.com/golang-jwt/jwt/v5"
+	"errors"
+	"slices"
+	"sync"
+
 	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
 	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/geo"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/membership"
 	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/paging"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/referral"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/region"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/sms"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/storage"
 	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
-	"time"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/zone"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 )
 
+// dummyPasswordHash is compared against when the username does not exist.
+// It is created on first use, after the configured hasher is selected.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := utils.HashPassword("dummy password for timing")
+	return hash
+})
+
+// userToProto converts a user row to its API representation.
+func userToProto(user *model.User) *userProto.User {
+	var deletionScheduledAt int64
+	if user.DeletionScheduledAt != nil {
+		deletionScheduledAt = user.DeletionScheduledAt.Unix()
+	}
+	return &userProto.User{
+		Id:                  uint32(user.ID),
+		Username:            user.Username,
+		Phone:               user.Phone,
+		Address:             user.Address,
+		Points:              int32(user.Points),
+		Role:                user.Role,
+		Disabled:            user.Disabled,
+		DeletionScheduledAt: deletionScheduledAt,
+		Nickname:            user.Nickname,
+		Email:               user.Email,
+		Gender:              user.Gender,
+		Birthday:            utils.FormatBirthday(user.Birthday),
+		PhoneVerified:       user.PhoneVerified,
+		AvatarUrl:           user.AvatarURL,
+		AvatarThumbnailUrl:  user.AvatarThumbnailURL,
+		Tier:                user.Tier,
+		ReferralCode:        stringValue(user.ReferralCode),
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 type UserHandler struct {
 	userProto.UnimplementedUserServiceServer
+
+	// SMS delivers one-time codes to phones.
+	SMS sms.Sender
+	// PasswordPolicy decides which new passwords are accepted.
+	PasswordPolicy *utils.PasswordPolicy
+	// Storage keeps uploaded avatars.
+	Storage storage.Storage
+	// Membership defines the loyalty tiers.
+	Membership *membership.Program
+	// Regions validates and parses addresses.
+	Regions *region.Dataset
+	// Zones decides which store delivers to an address.
+	Zones *zone.Index
+	// Geocoder locates addresses saved without coordinates.
+	Geocoder geo.Geocoder
 }
 
 func (h *UserHandler) Register(ctx context.Context, req *userProto.RegisterRequest) (*userProto.RegisterResponse, error) {
+	if err := h.validatePassword(req.Password); err != nil {
+		return &userProto.RegisterResponse{Code: 400, Message: "Invalid password"}, err
+	}
+
+	inviter, err := referralInviter(ctx, req.ReferralCode, req.DeviceId)
+	if err != nil {
+		return &userProto.RegisterResponse{Code: 400, Message: "Invalid referral code"}, err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return &userProto.RegisterResponse{Code: 500, Message: "Failed to hash password"}, err
 	}
+	referralCode, err := referral.NewCode()
+	if err != nil {
+		return &userProto.RegisterResponse{Code: 500, Message: "Failed to create referral code"}, err
+	}
 
 	user := model.User{
-		Username: req.Username,
-		Password: hashedPassword,
-		Phone:    req.Phone,
+		Username:       req.Username,
+		Password:       hashedPassword,
+		Phone:          req.Phone,
+		ReferralCode:   &referralCode,
+		SignupDeviceID: req.DeviceId,
 	}
-	if err = config.DB.WithContext(ctx).Create(&user).Error; err != nil {
-		return &userProto.RegisterResponse{Code: 400, Message: "Username already exists"}, err
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		if inviter != nil {
+			_, err := referral.Record(tx, inviter, &user)
+			return err
+		}
+		return nil
+	})
+	if utils.IsDuplicateEntry(err) && usernameTaken(ctx, req.Username) {
+		return &userProto.RegisterResponse{Code: 400, Message: "Username already exists"}, status.Error(codes.AlreadyExists, "username already exists")
+	}
+	if err != nil {
+		return &userProto.RegisterResponse{Code: 500, Message: "Failed to register"}, err
 	}
 
 	return &userProto.RegisterResponse{
 		Code:    0,
 		Message: "Success",
-		Data: &userProto.User{
-			Id:       uint32(user.ID),
-			Username: user.Username,
-			Phone:    user.Phone,
-			Address:  user.Address,
-			Points:   int32(user.Points),
-		},
+		Data:    userToProto(&user),
 	}, nil
 }
 
+// usernameTaken tells a duplicate username apart from collisions on the
+// other unique columns of a new user, such as its referral code.
+func usernameTaken(ctx context.Context, username string) bool {
+	var count int64
+	err := config.DB.WithContext(ctx).Model(&model.User{}).Where("username = ?", username).Count(&count).Error
+	return err == nil && count > 0
+}
+
 func (h *UserHandler) Login(ctx context.Context, req *userProto.LoginRequest) (*userProto.LoginResponse, error) {
-	var user model.User
-	if err := config.DB.WithContext(ctx).Where("username = ?", req.Username).First(&user).Error; err != nil {
-		return &userProto.LoginResponse{Code: 400, Message: "User not found"}, err
+	ip := clientIP(ctx)
+	wait, err := auth.CheckLoginLockout(ctx, auth.SubjectUser, req.Username, ip)
+	if err != nil {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to check login attempts"}, err
 	}
-
-	if !utils.CheckPassword(req.Password, user.Password) {
-		return &userProto.LoginResponse{Code: 400, Message: "Invalid password"}, nil
+	if wait > 0 {
+		return &userProto.LoginResponse{Code: 429, Message: "Too many failed attempts"},
+			status.Errorf(codes.ResourceExhausted, "too many failed login attempts, retry in %d seconds", int(wait.Seconds())+1)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
-	})
-	tokenStr, err := token.SignedString([]byte("your_jwt_secret"))
-	if err != nil {
-		return &userProto.LoginResponse{Code: 500, Message: "Failed to generate token"}, err
+	// Unknown usernames and wrong passwords get the same response and take
+	// the same time, so the login form cannot be used to discover accounts.
+	var user model.User
+	err = config.DB.WithContext(ctx).Where("username = ?", req.Username).First(&user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+	}
+	found := err == nil
+	hash := user.Password
+	if !found {
+		hash = dummyPasswordHash()
+	}
+	if !utils.CheckPassword(req.Password, hash) || !found {
+		if err := auth.RecordLoginFailure(ctx, auth.SubjectUser, req.Username, ip); err != nil {
+			return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+		}
+		return &userProto.LoginResponse{Code: 401, Message: "Invalid username or password"},
+			status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+	// With two-factor authentication on, the failures are only reset once
+	// the code was right too, in LoginWithTOTP.
+	if !user.TOTPEnabled {
+		if err := auth.RecordLoginSuccess(ctx, auth.SubjectUser, req.Username); err != nil {
+			return &userProto.LoginResponse{Code: 500, Message: "Failed to log in"}, err
+		}
+	}
+	if utils.NeedsRehash(user.Password) {
+		rehashPassword(ctx, &user, req.Password)
 	}
 
-	return &userProto.LoginResponse{
-		Code:    0,
-		Message: "Success",
-		Token:   tokenStr,
-	}, nil
+	return completeLogin(ctx, &user, req.DeviceName)
 }
 
 func (h *UserHandler) GetUserInfo(ctx context.Context, req *userProto.GetUserInfoRequest) (*userProto.GetUserInfoResponse, error) {
+	userID, err := callerID(ctx, req.UserId)
+	if err != nil {
+		return &userProto.GetUserInfoResponse{Code: 403, Message: "Permission denied"}, err
+	}
+
 	var user model.User
-	if err := config.DB.WithContext(ctx).First(&user, req.UserId).Error; err != nil {
+	if err := config.DB.WithContext(ctx).First(&user, userID).Error; err != nil {
 		return &userProto.GetUserInfoResponse{Code: 404, Message: "User not found"}, err
 	}
 
 	return &userProto.GetUserInfoResponse{
 		Code:    0,
 		Message: "Success",
-		Data: &userProto.User{
-			Id:       uint32(user.ID),
-			Username: user.Username,
-			Phone:    user.Phone,
-			Address:  user.Address,
-			Points:   int32(user.Points),
-		},
+		Data:    userToProto(&user),
 	}, nil
 }
 
 func (h *UserHandler) AddAddress(ctx context.Context, req *userProto.AddAddressRequest) (*userProto.AddAddressResponse, error) {
-	if req.IsDefault {
-		config.DB.WithContext(ctx).Model(&model.Address{}).Where("user_id = ? AND is_default = ?", req.UserId, true).Update("is_default", false)
+	userID, err := callerID(ctx, req.UserId)
+	if err != nil {
+		return &userProto.AddAddressResponse{Code: 403, Message: "Permission denied"}, err
 	}
 
 	address := model.Address{
-		UserID:        uint(req.UserId),
-		ReceiverName:  req.ReceiverName,
-		Phone:         req.Phone,
+		UserID:       uint(userID),
+		ReceiverName: req.ReceiverName,
+		Phone:        req.Phone,
+		IsDefault:    req.IsDefault,
+	}
+	err = h.setAddress(&address, addressInput{
 		AddressDetail: req.AddressDetail,
-		IsDefault:     req.IsDefault,
+		Place: region.Place{
+			ProvinceCode: req.ProvinceCode,
+			Province:     req.Province,
+			CityCode:     req.CityCode,
+			City:         req.City,
+			DistrictCode: req.DistrictCode,
+			District:     req.District,
+		},
+		Street:    req.Street,
+		Building:  req.Building,
+		Postcode:  req.Postcode,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if err != nil {
+		return &userProto.AddAddressResponse{Code: 400, Message: "Invalid address"}, err
 	}
-	if err := config.DB.Create(&address).Error; err != nil {
+	h.geocodeAddress(ctx, &address)
+
+	err = addressBookTx(ctx, userID, func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.Address{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			address.IsDefault = true
+		}
+		if err := tx.Create(&address).Error; err != nil {
+			return err
+		}
+		if address.IsDefault {
+			return clearOtherDefaults(tx, &address)
+		}
+		return nil
+	})
+	if err != nil {
 		return &userProto.AddAddressResponse{Code: 500, Message: "Failed to add address"}, err
 	}
 
 	return &userProto.AddAddressResponse{
 		Code:    0,
 		Message: "Success",
-		Data: &userProto.Address{
-			Id:            uint32(address.ID),
-			UserId:        uint32(address.UserID),
-			ReceiverName:  address.ReceiverName,
-			Phone:         address.Phone,
-			AddressDetail: address.AddressDetail,
-			IsDefault:     address.IsDefault,
-		},
+		Data:    h.addressToProto(&address),
 	}, nil
 }
 
 func (h *UserHandler) UpdateAddress(ctx context.Context, req *userProto.UpdateAddressRequest) (*userProto.UpdateAddressResponse, error) {
-	var address model.Address
-	if err := config.DB.WithContext(ctx).Where("id = ? AND user_id = ?", req.Id, req.UserId).First(&address).Error; err != nil {
-		return &userProto.UpdateAddressResponse{Code: 404, Message: "Address not found"}, err
+	userID, err := callerID(ctx, req.UserId)
+	if err != nil {
+		return &userProto.UpdateAddressResponse{Code: 403, Message: "Permission denied"}, err
 	}
 
-	if req.IsDefault {
-		config.DB.Model(&model.Address{}).Where("user_id = ? AND is_default = ?", req.UserId, true).Update("is_default", false)
+	paths, err := addressUpdatePaths(req)
+	if err != nil {
+		return &userProto.UpdateAddressResponse{Code: 400, Message: "Invalid update mask"}, err
 	}
 
-	address.ReceiverName = req.ReceiverName
-	address.Phone = req.Phone
-	address.AddressDetail = req.AddressDetail
-	address.IsDefault = req.IsDefault
-	if err := config.DB.Save(&address).Error; err != nil {
+	// Resolving and geocoding may call out to a provider, so they happen
+	// before the address book is locked, as in AddAddress.
+	address, err := ownAddress(config.DB.WithContext(ctx), req.Id, userID)
+	var columns []string
+	if err == nil {
+		columns, err = h.updateAddress(ctx, address, req, paths)
+	}
+	if err == nil {
+		err = addressBookTx(ctx, userID, func(tx *gorm.DB) error {
+			// Only the changed columns are written, so concurrent updates
+			// of other fields are kept. updated_at orders promoteDefault.
+			current, err := ownAddress(tx, req.Id, userID)
+			if err != nil {
+				return err
+			}
+			if len(columns) > 0 {
+				if err := tx.Model(current).Select(append(columns, "updated_at")).Updates(address).Error; err != nil {
+					return err
+				}
+			}
+			if slices.Contains(paths, "is_default") && !req.IsDefault && current.IsDefault {
+				// It became the default since it was checked above.
+				return errClearDefault
+			}
+			if slices.Contains(paths, "is_default") && req.IsDefault && !current.IsDefault {
+				if err := tx.Model(current).Update("is_default", true).Error; err != nil {
+					return err
+				}
+				if err := clearOtherDefaults(tx, current); err != nil {
+					return err
+				}
+			}
+			return tx.First(address, req.Id).Error
+		})
+	}
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return &userProto.UpdateAddressResponse{Code: 404, Message: "Address not found"}, err
+	case codes.PermissionDenied:
+		return &userProto.UpdateAddressResponse{Code: 403, Message: "Permission denied"}, err
+	case codes.InvalidArgument:
+		return &userProto.UpdateAddressResponse{Code: 400, Message: "Invalid address"}, err
+	default:
 		return &userProto.UpdateAddressResponse{Code: 500, Message: "Failed to update address"}, err
 	}
 
 	return &userProto.UpdateAddressResponse{
 		Code:    0,
 		Message: "Success",
-		Data: &userProto.Address{
-			Id:            uint32(address.ID),
-			UserId:        uint32(address.UserID),
-			ReceiverName:  address.ReceiverName,
-			Phone:         address.Phone,
-			AddressDetail: address.AddressDetail,
-			IsDefault:     address.IsDefault,
-		},
+		Data:    h.addressToProto(address),
+	}, nil
+}
+
+func (h *UserHandler) GetAddress(ctx context.Context, req *userProto.GetAddressRequest) (*userProto.GetAddressResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.GetAddressResponse{Code: 401, Message: "Unauthorized"}, err
+	}
+
+	address, err := ownAddress(config.DB.WithContext(ctx), req.Id, userID)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return &userProto.GetAddressResponse{Code: 404, Message: "Address not found"}, err
+	case codes.PermissionDenied:
+		return &userProto.GetAddressResponse{Code: 403, Message: "Permission denied"}, err
+	default:
+		return &userProto.GetAddressResponse{Code: 500, Message: "Failed to get address"}, err
+	}
+
+	return &userProto.GetAddressResponse{
+		Code:    0,
+		Message: "Success",
+		Data:    h.addressToProto(address),
 	}, nil
 }
 
 func (h *UserHandler) DeleteAddress(ctx context.Context, req *userProto.DeleteAddressRequest) (*userProto.DeleteAddressResponse, error) {
-	if err := config.DB.WithContext(ctx).Where("id = ? AND user_id = ?", req.Id, req.UserId).Delete(&model.Address{}).Error; err != nil {
+	userID, err := callerID(ctx, req.UserId)
+	if err != nil {
+		return &userProto.DeleteAddressResponse{Code: 403, Message: "Permission denied"}, err
+	}
+
+	err = addressBookTx(ctx, userID, func(tx *gorm.DB) error {
+		address, err := ownAddress(tx, req.Id, userID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(address).Error; err != nil {
+			return err
+		}
+		if address.IsDefault {
+			return promoteDefault(tx, address.UserID)
+		}
+		return nil
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
 		return &userProto.DeleteAddressResponse{Code: 404, Message: "Address not found"}, err
+	case codes.PermissionDenied:
+		return &userProto.DeleteAddressResponse{Code: 403, Message: "Permission denied"}, err
+	default:
+		return &userProto.DeleteAddressResponse{Code: 500, Message: "Failed to delete address"}, err
 	}
 
 	return &userProto.DeleteAddressResponse{
@@ -160,23 +388,70 @@ func (h *UserHandler) DeleteAddress(ctx context.Context, req *userProto.DeleteAd
 	}, nil
 }
 
-func (h *UserHandler) GetAddresses(ctx context.Context, req *userProto.GetAddressesRequest) (*userProto.GetAddressesResponse, error) {
-	var addresses []model.Address
-	config.DB.WithContext(ctx).Where("user_id = ?", req.UserId).Find(&addresses)
+func (h *UserHandler) SetDefaultAddress(ctx context.Context, req *userProto.SetDefaultAddressRequest) (*userProto.SetDefaultAddressResponse, error) {
+	userID, err := callerID(ctx, 0)
+	if err != nil {
+		return &userProto.SetDefaultAddressResponse{Code: 403, Message: "Permission denied"}, err
+	}
 
-	resp := &userProto.GetAddressesResponse{
+	var address *model.Address
+	err = addressBookTx(ctx, userID, func(tx *gorm.DB) error {
+		var err error
+		if address, err = ownAddress(tx, req.Id, userID); err != nil {
+			return err
+		}
+		if address.IsDefault {
+			return nil
+		}
+		if err := tx.Model(address).Update("is_default", true).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaults(tx, address)
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return &userProto.SetDefaultAddressResponse{Code: 404, Message: "Address not found"}, err
+	case codes.PermissionDenied:
+		return &userProto.SetDefaultAddressResponse{Code: 403, Message: "Permission denied"}, err
+	default:
+		return &userProto.SetDefaultAddressResponse{Code: 500, Message: "Failed to set default address"}, err
+	}
+
+	return &userProto.SetDefaultAddressResponse{
 		Code:    0,
 		Message: "Success",
+		Data:    h.addressToProto(address),
+	}, nil
+}
+
+func (h *UserHandler) GetAddresses(ctx context.Context, req *userProto.GetAddressesRequest) (*userProto.GetAddressesResponse, error) {
+	userID, err := callerID(ctx, req.UserId)
+	if err != nil {
+		return &userProto.GetAddressesResponse{Code: 403, Message: "Permission denied"}, err
 	}
-	for _, addr := range addresses {
-		resp.Addresses = append(resp.Addresses, &userProto.Address{
-			Id:            uint32(addr.ID),
-			UserId:        uint32(addr.UserID),
-			ReceiverName:  addr.ReceiverName,
-			Phone:         addr.Phone,
-			AddressDetail: addr.AddressDetail,
-			IsDefault:     addr.IsDefault,
-		})
+
+	query := config.DB.WithContext(ctx).Where("user_id = ?", userID)
+	addresses, next, err := paging.Find[model.Address](query, addressesList, paging.Request{
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		Filter:    req.Filter,
+		OrderBy:   req.OrderBy,
+	})
+	if errors.Is(err, paging.ErrInvalid) {
+		return &userProto.GetAddressesResponse{Code: 400, Message: "Invalid list request"}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return &userProto.GetAddressesResponse{Code: 500, Message: "Failed to list addresses"}, err
+	}
+
+	resp := &userProto.GetAddressesResponse{
+		Code:          0,
+		Message:       "Success",
+		NextPageToken: next,
+	}
+	for i := range addresses {
+		resp.Addresses = append(resp.Addresses, h.addressToProto(&addresses[i]))
 	}
 	return resp, nil
 }
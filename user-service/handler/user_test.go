@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegisterDuplicates(t *testing.T) {
+	testenv.Setup(t)
+	policy, err := utils.LoadPasswordPolicy(8, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &UserHandler{PasswordPolicy: policy}
+	ctx := context.Background()
+	register := func(username string) error {
+		_, err := h.Register(ctx, &userProto.RegisterRequest{Username: username, Password: "correct horse"})
+		return err
+	}
+
+	if err := register("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := register("alice"); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("taken username: got %v, want AlreadyExists", err)
+	}
+
+	// A collision on another unique column is not reported as a taken
+	// username.
+	if err := config.DB.Exec("CREATE UNIQUE INDEX idx_users_signup_device_id ON users (signup_device_id)").Error; err != nil {
+		t.Fatal(err)
+	}
+	err = register("bob")
+	if err == nil || status.Code(err) == codes.AlreadyExists {
+		t.Fatalf("other duplicate: got %v", err)
+	}
+	var count int64
+	config.DB.Model(&model.User{}).Where("username = ?", "bob").Count(&count)
+	if count != 0 {
+		t.Fatal("user created despite the error")
+	}
+}
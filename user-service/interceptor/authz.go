@@ -0,0 +1,47 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/authz"
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryAuthorize enforces the permissions declared in package authz. It must
+// run after UnaryAuthenticate.
+func UnaryAuthorize() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthorize is UnaryAuthorize for streaming methods.
+func StreamAuthorize() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func authorize(ctx context.Context, method string) error {
+	id, ok := identity.FromContext(ctx)
+	var role authz.Role
+	if ok {
+		role = authz.Role(id.Role)
+	}
+	switch authz.Check(method, ok, role) {
+	case authz.Unauthenticated:
+		return status.Error(codes.Unauthenticated, "authentication required")
+	case authz.Denied:
+		return status.Errorf(codes.PermissionDenied, "permission denied for %s", method)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package interceptor
+
+import (
+	"context"
+	"log"
+
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/identity"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"google.golang.org/grpc"
+)
+
+// UnarySessionActivity updates the last-seen time of the caller's login
+// session. Failures are logged and do not fail the request.
+func UnarySessionActivity() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if id, ok := identity.FromContext(ctx); ok && id.SessionID != "" {
+			if err := auth.TouchSession(ctx, id.SessionID); err != nil {
+				log.Printf("Failed to update session %s: %v", id.SessionID, err)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,52 @@
+// Package testenv points the service's global database and Redis clients at
+// throwaway stand-ins for tests: SQLite instead of MySQL and miniredis
+// instead of Redis.
+package testenv
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Setup migrates a fresh database into config.DB and connects
+// config.RedisClient to a fresh miniredis. Both are reset when the test
+// ends.
+func Setup(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(0)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		// Unique index violations surface as gorm.ErrDuplicatedKey, which
+		// utils.IsDuplicateEntry accepts like the MySQL error.
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("opening test database: %v", err)
+	}
+	if err := db.AutoMigrate(model.All...); err != nil {
+		t.Fatalf("migrating test database: %v", err)
+	}
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	prevDB, prevRedis := config.DB, config.RedisClient
+	config.DB, config.RedisClient = db, rdb
+	t.Cleanup(func() {
+		config.DB, config.RedisClient = prevDB, prevRedis
+		rdb.Close()
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return mr
+}
@@ -0,0 +1,108 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/region"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// addressBatchSize is the number of addresses loaded per query by
+// ParseLegacyAddresses.
+const addressBatchSize = 500
+
+// ParseLegacyAddresses fills the structured fields of addresses that only
+// have AddressDetail, from before addresses were structured. Parsing is best
+// effort; unrecognized text is kept as the street, which also marks the row
+// as done, so the migration can run at every start. The new columns are
+// NULL in rows that existed when they were added.
+func ParseLegacyAddresses(ctx context.Context, regions *region.Dataset) error {
+	var lastID uint
+	parsed, unrecognized := 0, 0
+	for {
+		var addresses []model.Address
+		err := config.DB.WithContext(ctx).
+			Where("id > ? AND COALESCE(province, '') = '' AND COALESCE(street, '') = '' AND address_detail <> ''", lastID).
+			Order("id").Limit(addressBatchSize).
+			Find(&addresses).Error
+		if err != nil {
+			return err
+		}
+		for _, a := range addresses {
+			p := regions.ParseAddress(a.AddressDetail)
+			if p.Province == "" {
+				unrecognized++
+			} else {
+				parsed++
+			}
+			err := config.DB.WithContext(ctx).Model(&a).
+				Select("province_code", "province", "city_code", "city", "district_code", "district", "street", "building", "postcode").
+				Updates(model.Address{
+					ProvinceCode: p.ProvinceCode,
+					Province:     p.Province,
+					CityCode:     p.CityCode,
+					City:         p.City,
+					DistrictCode: p.DistrictCode,
+					District:     p.District,
+					Street:       p.Street,
+					Building:     p.Building,
+					Postcode:     p.Postcode,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(addresses) < addressBatchSize {
+			break
+		}
+		lastID = addresses[len(addresses)-1].ID
+	}
+	if parsed+unrecognized > 0 {
+		log.Printf("Parsed %d legacy addresses, %d not recognized", parsed, unrecognized)
+	}
+	return nil
+}
+
+// RepairDefaultAddresses gives every user with addresses exactly one
+// default, which address books changed before the invariant was enforced
+// may lack. Of several defaults the most recently updated one is kept.
+func RepairDefaultAddresses(ctx context.Context) error {
+	var userIDs []uint
+	err := config.DB.WithContext(ctx).Model(&model.Address{}).
+		Group("user_id").
+		Having("SUM(CASE WHEN is_default THEN 1 ELSE 0 END) <> 1").
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var user model.User
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, userID).Error; err != nil {
+				return err
+			}
+			var keep model.Address
+			err := tx.Where("user_id = ?", userID).Order("is_default DESC, updated_at DESC, id DESC").First(&keep).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&model.Address{}).Where("user_id = ? AND id <> ?", userID, keep.ID).Update("is_default", false).Error; err != nil {
+				return err
+			}
+			return tx.Model(&keep).Update("is_default", true).Error
+		})
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("repairing default address of user %d: %w", userID, err)
+		}
+	}
+	if len(userIDs) > 0 {
+		log.Printf("Repaired the default address of %d users", len(userIDs))
+	}
+	return nil
+}
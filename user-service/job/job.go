@@ -0,0 +1,31 @@
+// Package job runs the periodic background work of the user-service.
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs fn every interval until ctx is done. Errors are logged and the
+// job keeps running. A zero interval disables the job.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(context.Context) error) {
+	if interval <= 0 {
+		log.Printf("Job %s is disabled", name)
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := fn(ctx); err != nil {
+				log.Printf("Job %s failed: %v", name, err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
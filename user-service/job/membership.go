@@ -0,0 +1,43 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/membership"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+)
+
+// reviewBatchSize is the number of users loaded per query by
+// ReviewMemberships.
+const reviewBatchSize = 500
+
+// ReviewMemberships returns the job that re-evaluates the tier of every
+// user. Upgrades are applied as points are earned; the job catches tiers
+// that are due for review and tiers affected by configuration changes.
+func ReviewMemberships(program *membership.Program) func(context.Context) error {
+	return func(ctx context.Context) error {
+		now := time.Now()
+		var lastID uint
+		for {
+			var ids []uint
+			err := config.DB.WithContext(ctx).Model(&model.User{}).
+				Where("id > ?", lastID).Order("id").Limit(reviewBatchSize).
+				Pluck("id", &ids).Error
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if _, err := program.Evaluate(ctx, id, now); err != nil {
+					return fmt.Errorf("reviewing user %d: %w", id, err)
+				}
+			}
+			if len(ids) < reviewBatchSize {
+				return nil
+			}
+			lastID = ids[len(ids)-1]
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/points"
+	"gorm.io/gorm"
+)
+
+// ExpirePoints removes the points of all lots that have expired and records
+// them in the ledger.
+func ExpirePoints(ctx context.Context) error {
+	now := time.Now()
+	var userIDs []uint
+	err := config.DB.WithContext(ctx).Model(&model.PointsLot{}).
+		Where("remaining > 0 AND expires_at <= ?", now).
+		Distinct().Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, id := range userIDs {
+		entry, err := points.Expire(ctx, id, now)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("expiring points of user %d: %w", id, err)
+		}
+		if entry != nil {
+			log.Printf("Expired %d points of user %d", -entry.Delta, id)
+		}
+	}
+	return nil
+}
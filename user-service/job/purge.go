@@ -0,0 +1,120 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/avatar"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/storage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PurgeDeletedAccounts returns the job that purges every account whose
+// deletion grace period has ended, including the avatar kept in store.
+func PurgeDeletedAccounts(store storage.Storage) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return purgeDeletedAccounts(ctx, store)
+	}
+}
+
+func purgeDeletedAccounts(ctx context.Context, store storage.Storage) error {
+	var ids []uint
+	err := config.DB.WithContext(ctx).Model(&model.User{}).
+		Where("deletion_scheduled_at <= ?", time.Now()).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := purgeAccount(ctx, store, id); err != nil {
+			return fmt.Errorf("purging user %d: %w", id, err)
+		}
+		log.Printf("Purged account of user %d", id)
+	}
+	return nil
+}
+
+// purgeAccount removes the personal data of one user. Another replica may
+// purge the same user concurrently, so the row is locked and rechecked.
+func purgeAccount(ctx context.Context, store storage.Storage, userID uint) error {
+	var avatarKey string
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deletion_scheduled_at <= ?", userID, time.Now()).
+			First(&user).Error
+		if err != nil {
+			return err
+		}
+		avatarKey = user.AvatarKey
+
+		for _, m := range []interface{}{&model.Address{}, &model.RecoveryCode{}, &model.Session{}, &model.PointsLot{}} {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(m).Error; err != nil {
+				return err
+			}
+		}
+
+		if config.AccountPurgeMode == "delete" {
+			if err := tx.Where("user_id = ?", userID).Delete(&model.PointsTransaction{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("user_id = ?", userID).Delete(&model.TierChange{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("inviter_id = ? OR invitee_id = ?", userID, userID).Delete(&model.Referral{}).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Delete(&user).Error
+		}
+		err = tx.Model(&model.Referral{}).Where("invitee_id = ?", userID).
+			Updates(map[string]interface{}{"phone": "", "device_id": ""}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&user).Updates(map[string]interface{}{
+			"username":              fmt.Sprintf("deleted-%d", userID),
+			"password":              "",
+			"phone":                 "",
+			"phone_verified":        false,
+			"address":               "",
+			"nickname":              "",
+			"email":                 "",
+			"gender":                "",
+			"birthday":              nil,
+			"avatar_key":            "",
+			"avatar_url":            "",
+			"avatar_thumbnail_url":  "",
+			"referral_code":         nil,
+			"signup_device_id":      "",
+			"totp_secret":           "",
+			"totp_enabled":          false,
+			"disabled":              true,
+			"disabled_reason":       "account deleted",
+			"deletion_scheduled_at": nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&user).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if avatarKey != "" {
+		if err := avatar.Delete(ctx, store, avatarKey); err != nil {
+			log.Printf("Failed to delete avatar %s of purged user %d: %v", avatarKey, userID, err)
+		}
+	}
+	// Logins during the grace period may have started new sessions.
+	return auth.SignOutEverywhere(ctx, userID)
+}
@@ -1,32 +1,147 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 
+	"github.com/yinxi0607/YixiGroceryAPI/pkg/grpctls"
 	userProto "github.com/yinxi0607/YixiGroceryAPI/proto/user"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/auth"
 	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/geo"
 	"github.com/yinxi0607/YixiGroceryAPI/user-service/handler"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/interceptor"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/job"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/membership"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/region"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/sms"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/storage"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/zone"
 	"google.golang.org/grpc"
 )
 
 func main() {
 	// Initialize database
 	config.InitDB()
+	config.InitAuth()
+	config.InitAccount()
+	config.InitServer()
+	config.InitStorage()
+	config.InitMembership()
+	config.InitPoints()
+	config.InitReferral()
+	config.InitAddress()
+	auth.InitKeys()
 
 	// Create gRPC server
-	srv := grpc.NewServer()
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptor.UnaryAuthenticate(), interceptor.UnaryAuthorize(), interceptor.UnarySessionActivity()),
+		grpc.ChainStreamInterceptor(interceptor.StreamAuthenticate(), interceptor.StreamAuthorize()),
+		// Avatar uploads carry the whole image in one message.
+		grpc.MaxRecvMsgSize(config.AvatarMaxBytes + 64<<10),
+	}
+	if config.TLSCertFile != "" {
+		creds, err := grpctls.ServerCredentials(config.TLSCertFile, config.TLSKeyFile, config.TLSClientCAFile)
+		if err != nil {
+			log.Fatalf("Failed to load TLS credentials: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	srv := grpc.NewServer(opts...)
+
+	smsSender, err := sms.New(config.SMSSender, config.SMSFilePath)
+	if err != nil {
+		log.Fatalf("Failed to create SMS sender: %v", err)
+	}
+
+	hasher, err := utils.NewPasswordHasher(config.PasswordHashAlgorithm, utils.Argon2idParams{
+		Memory:  uint32(config.Argon2Memory),
+		Time:    uint32(config.Argon2Time),
+		Threads: uint8(config.Argon2Threads),
+	}, config.BcryptCost)
+	if err != nil {
+		log.Fatalf("Failed to create password hasher: %v", err)
+	}
+	utils.SetPasswordHasher(hasher)
+
+	passwordPolicy, err := utils.LoadPasswordPolicy(config.PasswordMinLength, config.PasswordDenylistFile)
+	if err != nil {
+		log.Fatalf("Failed to load password policy: %v", err)
+	}
+
+	store, err := storage.New(storage.Config{
+		Backend:   config.StorageBackend,
+		PublicURL: config.StoragePublicURL,
+		LocalDir:  config.StorageLocalDir,
+		S3: storage.S3Config{
+			Endpoint:        config.S3Endpoint,
+			Region:          config.S3Region,
+			Bucket:          config.S3Bucket,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+			PathStyle:       config.S3PathStyle,
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create storage: %v", err)
+	}
+
+	program, err := membership.Load(config.MembershipTiersFile)
+	if err != nil {
+		log.Fatalf("Failed to load membership tiers: %v", err)
+	}
+
+	regions, err := region.Load(config.RegionDataFile)
+	if err != nil {
+		log.Fatalf("Failed to load region dataset: %v", err)
+	}
+	zones, err := zone.Load(config.DeliveryZonesDir)
+	if err != nil {
+		log.Fatalf("Failed to load delivery zones: %v", err)
+	}
+	if zones.Len() == 0 {
+		log.Println("No delivery zones configured, no address is deliverable")
+	}
+	geocoder, err := geo.New(geo.Config{
+		Provider:      config.GeocoderProvider,
+		GazetteerFile: config.GeocoderGazetteerFile,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create geocoder: %v", err)
+	}
+	go func() {
+		if err := job.ParseLegacyAddresses(context.Background(), regions); err != nil {
+			log.Printf("Failed to parse legacy addresses: %v", err)
+		}
+		if err := job.RepairDefaultAddresses(context.Background()); err != nil {
+			log.Printf("Failed to repair default addresses: %v", err)
+		}
+	}()
+
+	job.Every(context.Background(), "purge deleted accounts", config.AccountPurgeInterval, job.PurgeDeletedAccounts(store))
+	job.Every(context.Background(), "expire points", config.PointsExpiryInterval, job.ExpirePoints)
+	job.Every(context.Background(), "review memberships", config.MembershipReviewInterval, job.ReviewMemberships(program))
 
 	// Register UserService
-	userProto.RegisterUserServiceServer(srv, &handler.UserHandler{})
+	userProto.RegisterUserServiceServer(srv, &handler.UserHandler{
+		SMS:            smsSender,
+		PasswordPolicy: passwordPolicy,
+		Storage:        store,
+		Membership:     program,
+		Regions:        regions,
+		Zones:          zones,
+		Geocoder:       geocoder,
+	})
 
 	// Start gRPC server
-	lis, err := net.Listen("tcp", ":8081")
+	lis, err := net.Listen("tcp", config.GRPCListenAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	log.Println("Starting gRPC server on :8081")
+	log.Printf("Starting gRPC server on %s", config.GRPCListenAddr)
 	if err := srv.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
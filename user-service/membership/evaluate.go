@@ -0,0 +1,192 @@
+package membership
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxStreamLength caps the tier change stream; consumers are expected to
+// keep up well within it.
+const maxStreamLength = 100000
+
+// Status is the membership of one user.
+type Status struct {
+	Tier Tier
+	// Next is nil at the top tier.
+	Next           *Tier
+	LifetimePoints int
+	TierSince      *time.Time
+	// ReviewAt is nil at the lowest tier.
+	ReviewAt *time.Time
+}
+
+// ProgressPercent is the progress from the current to the next tier.
+func (s *Status) ProgressPercent() int {
+	if s.Next == nil {
+		return 100
+	}
+	progress := (s.LifetimePoints - s.Tier.Threshold) * 100 / (s.Next.Threshold - s.Tier.Threshold)
+	return min(max(progress, 0), 100)
+}
+
+// PointsToNext is the number of lifetime points still missing for the next
+// tier.
+func (s *Status) PointsToNext() int {
+	if s.Next == nil {
+		return 0
+	}
+	return max(s.Next.Threshold-s.LifetimePoints, 0)
+}
+
+// Evaluate recomputes the tier of a user. Users move up as soon as they
+// qualify for a higher tier; they move down only when their tier is due for
+// review. Every change is recorded and published as an event.
+func (p *Program) Evaluate(ctx context.Context, userID uint, now time.Time) (*Status, error) {
+	var status *Status
+	var change *model.TierChange
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "tier", "tier_since", "tier_review_at").
+			First(&user, userID).Error
+		if err != nil {
+			return err
+		}
+
+		lifetime, err := p.lifetimePoints(tx, userID, now)
+		if err != nil {
+			return err
+		}
+
+		current := p.index(user.Tier)
+		qualified := p.qualify(lifetime)
+		next, since, reviewAt := current, user.TierSince, user.TierReviewAt
+		switch {
+		case qualified > current:
+			next, since = qualified, &now
+			reviewAt = timePtr(now.Add(p.reviewPeriod(qualified)))
+		case current > 0 && (reviewAt == nil || !reviewAt.After(now)):
+			next = qualified
+			if next != current {
+				since = &now
+			}
+			reviewAt = nil
+			if next > 0 {
+				reviewAt = timePtr(now.Add(p.reviewPeriod(next)))
+			}
+		}
+		if since == nil {
+			since = &now
+		}
+
+		name := p.Tiers[next].Name
+		if name != user.Tier || !sameTime(since, user.TierSince) || !sameTime(reviewAt, user.TierReviewAt) {
+			// Updating through &user would overwrite user.Tier, which the
+			// change record below still needs.
+			err := tx.Model(&model.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+				"tier":           name,
+				"tier_since":     since,
+				"tier_review_at": reviewAt,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		// Users without a tier yet silently start at the lowest one.
+		if name != user.Tier && !(user.Tier == "" && next == 0) {
+			change = &model.TierChange{UserID: userID, FromTier: user.Tier, ToTier: name, LifetimePoints: lifetime}
+			if err := tx.Create(change).Error; err != nil {
+				return err
+			}
+		}
+
+		status = p.status(next, lifetime, since, reviewAt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if change != nil {
+		publish(ctx, change)
+	}
+	return status, nil
+}
+
+// Status returns the stored membership of a user together with the
+// lifetime points earned so far. Unlike Evaluate it neither locks nor
+// changes anything, so a pending tier change only shows once Evaluate ran.
+func (p *Program) Status(ctx context.Context, userID uint, now time.Time) (*Status, error) {
+	db := config.DB.WithContext(ctx)
+	var user model.User
+	err := db.Select("id", "tier", "tier_since", "tier_review_at").First(&user, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	lifetime, err := p.lifetimePoints(db, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	return p.status(p.index(user.Tier), lifetime, user.TierSince, user.TierReviewAt), nil
+}
+
+// lifetimePoints sums the points the user earned within the window.
+func (p *Program) lifetimePoints(db *gorm.DB, userID uint, now time.Time) (int, error) {
+	var lifetime int
+	err := db.Model(&model.PointsTransaction{}).
+		Where("user_id = ? AND type = ? AND created_at >= ?", userID, model.PointsEarn, now.Add(-p.Window())).
+		Select("COALESCE(SUM(delta), 0)").Scan(&lifetime).Error
+	return lifetime, err
+}
+
+func (p *Program) status(tier, lifetime int, since, reviewAt *time.Time) *Status {
+	s := &Status{Tier: p.Tiers[tier], LifetimePoints: lifetime, TierSince: since, ReviewAt: reviewAt}
+	if tier+1 < len(p.Tiers) {
+		s.Next = &p.Tiers[tier+1]
+	}
+	return s
+}
+
+// publish emits a tier change to the event stream. The change is already
+// recorded, so failures are only logged.
+func publish(ctx context.Context, change *model.TierChange) {
+	log.Printf("Membership of user %d changed from %q to %q", change.UserID, change.FromTier, change.ToTier)
+	if config.MembershipEventStream == "" {
+		return
+	}
+	err := config.RedisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: config.MembershipEventStream,
+		MaxLen: maxStreamLength,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type":            "tier_changed",
+			"id":              strconv.FormatUint(uint64(change.ID), 10),
+			"user_id":         strconv.FormatUint(uint64(change.UserID), 10),
+			"from_tier":       change.FromTier,
+			"to_tier":         change.ToTier,
+			"lifetime_points": change.LifetimePoints,
+			"changed_at":      change.CreatedAt.Unix(),
+		},
+	}).Err()
+	if err != nil {
+		log.Printf("Failed to publish tier change %d: %v", change.ID, err)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
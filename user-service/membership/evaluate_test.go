@@ -0,0 +1,82 @@
+package membership
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+)
+
+func earned(t *testing.T, userID uint, points int, at time.Time) {
+	t.Helper()
+	entry := model.PointsTransaction{UserID: userID, Type: model.PointsEarn, Delta: points, CreatedAt: at}
+	if err := config.DB.Create(&entry).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func storedTier(t *testing.T, userID uint) string {
+	t.Helper()
+	var user model.User
+	if err := config.DB.First(&user, userID).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user.Tier
+}
+
+func TestEvaluate(t *testing.T) {
+	testenv.Setup(t)
+	config.MembershipEventStream = ""
+	p := DefaultProgram()
+	ctx := context.Background()
+	now := time.Now()
+
+	user := model.User{Username: "alice", Password: "x"}
+	if err := config.DB.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	earned(t, user.ID, 999, now.Add(-time.Hour))
+	// Points earned before the window do not count.
+	earned(t, user.ID, 5000, now.Add(-p.Window()-time.Hour))
+
+	s, err := p.Evaluate(ctx, user.ID, now)
+	if err != nil || s.Tier.Name != "member" || s.LifetimePoints != 999 || s.ReviewAt != nil {
+		t.Fatalf("999 points: %+v, %v", s, err)
+	}
+
+	earned(t, user.ID, 1, now)
+	// Status only reads: the upgrade is pending until Evaluate runs.
+	s, err = p.Status(ctx, user.ID, now)
+	if err != nil || s.Tier.Name != "member" || s.LifetimePoints != 1000 || s.ProgressPercent() != 100 {
+		t.Fatalf("status at 1000 points: %+v, %v", s, err)
+	}
+	if tier := storedTier(t, user.ID); tier != "member" {
+		t.Fatalf("Status changed the tier to %q", tier)
+	}
+
+	s, err = p.Evaluate(ctx, user.ID, now)
+	if err != nil || s.Tier.Name != "silver" || s.Next.Name != "gold" || s.ReviewAt == nil {
+		t.Fatalf("1000 points: %+v, %v", s, err)
+	}
+	var changes []model.TierChange
+	if err := config.DB.Where("user_id = ?", user.ID).Find(&changes).Error; err != nil || len(changes) != 1 || changes[0].FromTier != "member" || changes[0].ToTier != "silver" {
+		t.Fatalf("tier changes %+v, %v", changes, err)
+	}
+
+	// The tier is kept until the review, however few points are left in
+	// the window by then.
+	later := now.Add(p.Window() / 2)
+	if s, err := p.Evaluate(ctx, user.ID, later); err != nil || s.Tier.Name != "silver" {
+		t.Fatalf("before the review: %+v, %v", s, err)
+	}
+	review := s.ReviewAt.Add(time.Second)
+	if s, err := p.Evaluate(ctx, user.ID, review); err != nil || s.Tier.Name != "member" || s.ReviewAt != nil {
+		t.Fatalf("at the review: %+v, %v", s, err)
+	}
+	if tier := storedTier(t, user.ID); tier != "member" {
+		t.Fatalf("stored tier %q after the review", tier)
+	}
+}
@@ -0,0 +1,129 @@
+// Package membership computes loyalty tiers from the points users earned
+// within a rolling window.
+package membership
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Tier is one membership level. Users qualify for a tier when their lifetime
+// points reach its Threshold.
+type Tier struct {
+	Name      string   `json:"name"`
+	Threshold int      `json:"threshold"`
+	Benefits  []string `json:"benefits"`
+	// ReviewDays overrides Program.ReviewDays for this tier.
+	ReviewDays int `json:"review_days,omitempty"`
+}
+
+// Program holds the tier definitions.
+type Program struct {
+	// WindowDays is the rolling window lifetime points are summed over.
+	WindowDays int `json:"window_days"`
+	// ReviewDays is how long a tier is kept once reached. At the review the
+	// tier is renewed if the user still qualifies and lowered otherwise.
+	ReviewDays int `json:"review_days"`
+	// Tiers are ordered by ascending Threshold; the first one has threshold
+	// zero and is the tier of every user.
+	Tiers []Tier `json:"tiers"`
+}
+
+// DefaultProgram is used when no tiers file is configured.
+func DefaultProgram() *Program {
+	return &Program{
+		WindowDays: 365,
+		ReviewDays: 365,
+		Tiers: []Tier{
+			{Name: "member", Threshold: 0},
+			{Name: "silver", Threshold: 1000, Benefits: []string{"Free delivery on orders over 49"}},
+			{Name: "gold", Threshold: 5000, Benefits: []string{"Free delivery", "5% off fresh produce"}},
+			{Name: "platinum", Threshold: 20000, Benefits: []string{"Free delivery", "10% off fresh produce", "Priority support"}},
+		},
+	}
+}
+
+// Load reads the program from a JSON file, or returns DefaultProgram when
+// path is empty.
+func Load(path string) (*Program, error) {
+	if path == "" {
+		return DefaultProgram(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Program
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &p, nil
+}
+
+func (p *Program) validate() error {
+	if p.WindowDays <= 0 || p.ReviewDays <= 0 {
+		return errors.New("window_days and review_days must be positive")
+	}
+	if len(p.Tiers) == 0 || p.Tiers[0].Threshold != 0 {
+		return errors.New("the first tier must have threshold 0")
+	}
+	names := make(map[string]bool)
+	for i, t := range p.Tiers {
+		if t.Name == "" || len(t.Name) > 20 {
+			return fmt.Errorf("tier %d: name must have 1 to 20 characters", i)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("tier %q is defined twice", t.Name)
+		}
+		names[t.Name] = true
+		if i > 0 && t.Threshold <= p.Tiers[i-1].Threshold {
+			return fmt.Errorf("tier %q: thresholds must be ascending", t.Name)
+		}
+		if t.ReviewDays < 0 {
+			return fmt.Errorf("tier %q: review_days must not be negative", t.Name)
+		}
+	}
+	return nil
+}
+
+// Window is the period lifetime points are summed over.
+func (p *Program) Window() time.Duration {
+	return time.Duration(p.WindowDays) * 24 * time.Hour
+}
+
+// reviewPeriod is how long tier i is kept before it is reviewed.
+func (p *Program) reviewPeriod(i int) time.Duration {
+	days := p.ReviewDays
+	if p.Tiers[i].ReviewDays > 0 {
+		days = p.Tiers[i].ReviewDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// index returns the position of the named tier. Unknown names, such as
+// tiers removed from the configuration, map to the lowest tier.
+func (p *Program) index(name string) int {
+	for i, t := range p.Tiers {
+		if t.Name == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// qualify returns the highest tier the lifetime points reach.
+func (p *Program) qualify(points int) int {
+	i := 0
+	for j, t := range p.Tiers {
+		if points >= t.Threshold {
+			i = j
+		}
+	}
+	return i
+}
@@ -0,0 +1,67 @@
+package membership
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestQualifyBoundaries(t *testing.T) {
+	p := DefaultProgram()
+	for _, tc := range []struct {
+		points   int
+		tier     string
+		toNext   int
+		progress int
+	}{
+		{0, "member", 1000, 0},
+		{999, "member", 1, 99},
+		{1000, "silver", 4000, 0},
+		{3000, "silver", 2000, 50},
+		{4999, "silver", 1, 99},
+		{5000, "gold", 15000, 0},
+		{19999, "gold", 1, 99},
+		{20000, "platinum", 0, 100},
+		{1000000, "platinum", 0, 100},
+	} {
+		s := p.status(p.qualify(tc.points), tc.points, nil, nil)
+		if s.Tier.Name != tc.tier || s.PointsToNext() != tc.toNext || s.ProgressPercent() != tc.progress {
+			t.Errorf("%d points: tier %s, %d to next, %d%%; want %s, %d, %d%%",
+				tc.points, s.Tier.Name, s.PointsToNext(), s.ProgressPercent(), tc.tier, tc.toNext, tc.progress)
+		}
+	}
+
+	// A user kept in a tier above what they qualify for until the review
+	// shows full progress rather than a negative one.
+	s := p.status(p.index("gold"), 1500, nil, nil)
+	if s.ProgressPercent() != 0 || s.PointsToNext() != 18500 {
+		t.Errorf("gold with 1500 points: %d%%, %d to next", s.ProgressPercent(), s.PointsToNext())
+	}
+	if p.index("diamond") != 0 {
+		t.Error("unknown tier does not map to the lowest one")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	if p, err := Load(""); err != nil || len(p.Tiers) != 4 {
+		t.Fatalf("default program: %v", err)
+	}
+	for name, content := range map[string]string{
+		"no window":        `{"review_days": 1, "tiers": [{"name": "a", "threshold": 0}]}`,
+		"no tiers":         `{"window_days": 1, "review_days": 1, "tiers": []}`,
+		"first above zero": `{"window_days": 1, "review_days": 1, "tiers": [{"name": "a", "threshold": 5}]}`,
+		"descending":       `{"window_days": 1, "review_days": 1, "tiers": [{"name": "a", "threshold": 0}, {"name": "b", "threshold": 10}, {"name": "c", "threshold": 10}]}`,
+		"duplicate name":   `{"window_days": 1, "review_days": 1, "tiers": [{"name": "a", "threshold": 0}, {"name": "a", "threshold": 10}]}`,
+		"negative review":  `{"window_days": 1, "review_days": 1, "tiers": [{"name": "a", "threshold": 0, "review_days": -1}]}`,
+		"not json":         `{`,
+		"name too long":    `{"window_days": 1, "review_days": 1, "tiers": [{"name": "aaaaaaaaaaaaaaaaaaaaa", "threshold": 0}]}`,
+	} {
+		file := filepath.Join(t.TempDir(), "tiers.json")
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(file); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
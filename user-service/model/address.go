@@ -4,9 +4,25 @@ import "gorm.io/gorm"
 
 type Address struct {
 	gorm.Model
-	UserID        uint   `gorm:"not null"`
-	ReceiverName  string `gorm:"not null"`
-	Phone         string `gorm:"not null"`
+	UserID       uint   `gorm:"not null"`
+	ReceiverName string `gorm:"not null"`
+	Phone        string `gorm:"not null"`
+	// AddressDetail is the whole address on one line. It is derived from the
+	// structured fields below; rows from before those existed were parsed
+	// into them on a best-effort basis.
 	AddressDetail string `gorm:"not null"`
-	IsDefault     bool   `gorm:"default:false"`
+	// The region codes are GB/T 2260 codes, empty where the region dataset
+	// does not cover the level.
+	ProvinceCode string `gorm:"size:6"`
+	Province     string `gorm:"size:50"`
+	CityCode     string `gorm:"size:6;index"`
+	City         string `gorm:"size:50"`
+	DistrictCode string `gorm:"size:6"`
+	District     string `gorm:"size:50"`
+	Street       string `gorm:"size:255"`
+	Building     string `gorm:"size:255"`
+	Postcode     string `gorm:"size:6"`
+	Latitude     *float64
+	Longitude    *float64
+	IsDefault    bool `gorm:"default:false"`
 }
@@ -0,0 +1,6 @@
+package model
+
+// All lists every model of the service, in the order they are migrated.
+var All = []interface{}{
+	&User{}, &Address{}, &RecoveryCode{}, &Session{}, &PointsTransaction{}, &TierChange{}, &PointsLot{}, &Referral{},
+}
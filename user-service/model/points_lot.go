@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// PointsLot is a grant of points that is consumed first-in-first-out and
+// expires as a whole. The remaining amounts of a user's lots never exceed
+// User.Points; balances from before lots were introduced are not tracked by
+// any lot.
+type PointsLot struct {
+	ID     uint `gorm:"primaryKey"`
+	UserID uint `gorm:"not null;index:idx_points_lots_user_expiry,priority:1"`
+	// TransactionID is the ledger entry that granted the points.
+	TransactionID uint `gorm:"not null"`
+	Amount        int  `gorm:"not null"`
+	Remaining     int  `gorm:"not null"`
+	// ExpiresAt is nil when points do not expire.
+	ExpiresAt *time.Time `gorm:"index:idx_points_lots_user_expiry,priority:2;index"`
+	CreatedAt time.Time
+}
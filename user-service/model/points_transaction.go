@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Points transaction types.
+const (
+	PointsEarn   = "earn"
+	PointsRedeem = "redeem"
+	PointsAdjust = "adjust"
+	PointsExpire = "expire"
+)
+
+// PointsTransaction is one entry of the points ledger. The ledger is append
+// only; User.Points always equals the BalanceAfter of the user's latest entry.
+type PointsTransaction struct {
+	ID     uint   `gorm:"primaryKey"`
+	UserID uint   `gorm:"not null;index;uniqueIndex:idx_points_transactions_reference,priority:1"`
+	Type   string `gorm:"size:20;not null;uniqueIndex:idx_points_transactions_reference,priority:2"`
+	Delta  int    `gorm:"not null"`
+	Reason string `gorm:"size:255"`
+	// ReferenceID identifies the business event, e.g. an order, and makes
+	// retries of the same event idempotent. Adjustments may have none.
+	ReferenceID  *string `gorm:"size:64;uniqueIndex:idx_points_transactions_reference,priority:3"`
+	BalanceAfter int     `gorm:"not null"`
+	CreatedAt    time.Time
+}
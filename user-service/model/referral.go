@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Referral statuses.
+const (
+	ReferralPending  = "pending"
+	ReferralRewarded = "rewarded"
+	ReferralRejected = "rejected"
+)
+
+// Referral links a new user to the user whose referral code they signed up
+// with. Rejected referrals are kept so their phone and device count for the
+// abuse checks of later sign-ups.
+type Referral struct {
+	ID        uint   `gorm:"primaryKey"`
+	InviterID uint   `gorm:"not null;index"`
+	InviteeID uint   `gorm:"not null;uniqueIndex"`
+	Status    string `gorm:"size:20;not null"`
+	// RejectReason tells why a referral is not rewarded, e.g. "same_phone".
+	RejectReason string `gorm:"size:50"`
+	// Phone and DeviceID are those of the invitee at sign-up.
+	Phone    string `gorm:"size:20;index"`
+	DeviceID string `gorm:"size:64;index"`
+	// OrderID is the first order of the invitee that earned the rewards.
+	OrderID    string `gorm:"size:64"`
+	CreatedAt  time.Time
+	RewardedAt *time.Time
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Session is one login of a user on a device. Its ID doubles as the refresh
+// token family and is embedded in every access token as the sid claim, so
+// revoking a session signs out exactly that device.
+type Session struct {
+	ID         string `gorm:"primaryKey;size:32"`
+	UserID     uint   `gorm:"not null;index"`
+	DeviceName string `gorm:"size:100"`
+	UserAgent  string `gorm:"size:255"`
+	IP         string `gorm:"size:45"`
+	// TokenID is the jti of the latest access token issued to the session.
+	TokenID    string `gorm:"size:32"`
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+}
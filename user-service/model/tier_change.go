@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// TierChange records a change of a user's membership tier.
+type TierChange struct {
+	ID       uint   `gorm:"primaryKey"`
+	UserID   uint   `gorm:"not null;index"`
+	FromTier string `gorm:"size:20"`
+	ToTier   string `gorm:"size:20;not null"`
+	// LifetimePoints are the points within the window at the time of the
+	// change.
+	LifetimePoints int `gorm:"not null"`
+	CreatedAt      time.Time
+}
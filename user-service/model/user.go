@@ -1,12 +1,52 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	gorm.Model
 	Username string `gorm:"unique;not null"`
 	Password string `gorm:"not null"`
-	Phone    string
-	Address  string
-	Points   int `gorm:"default:0"`
+	Phone    string `gorm:"index"`
+	// PhoneVerified is set once the user proved ownership of Phone with a
+	// one-time code.
+	PhoneVerified bool `gorm:"default:false"`
+	Address       string
+	Nickname      string `gorm:"size:50"`
+	Email         string `gorm:"size:254"`
+	// Gender is "male", "female", "other" or empty.
+	Gender   string     `gorm:"size:10"`
+	Birthday *time.Time `gorm:"type:date"`
+	// AvatarKey is the storage key prefix of the avatar images; the URLs are
+	// kept so they need not be recomputed on every read.
+	AvatarKey          string `gorm:"size:255"`
+	AvatarURL          string `gorm:"size:512"`
+	AvatarThumbnailURL string `gorm:"size:512"`
+	Points             int    `gorm:"default:0"`
+	// Tier is the membership tier, see package membership. TierReviewAt is
+	// when it is reviewed next; it is nil at the lowest tier.
+	Tier         string `gorm:"size:20"`
+	TierSince    *time.Time
+	TierReviewAt *time.Time `gorm:"index"`
+	// TOTPSecret is the AES-GCM encrypted TOTP secret. It is set by
+	// EnableTOTP but only enforced once TOTPEnabled is confirmed.
+	TOTPSecret  string
+	TOTPEnabled bool `gorm:"default:false"`
+	// Role is one of the roles of package authz and is embedded in the JWT.
+	Role string `gorm:"size:20;not null;default:customer"`
+	// Disabled accounts cannot log in or refresh tokens.
+	Disabled       bool `gorm:"default:false"`
+	DisabledReason string
+	// ReferralCode is the code others sign up with to credit this user. It
+	// is nil for accounts created before referrals until they first ask for
+	// it.
+	ReferralCode *string `gorm:"size:16;uniqueIndex"`
+	// SignupDeviceID identifies the device the account was created on.
+	SignupDeviceID string `gorm:"size:64"`
+	// DeletionScheduledAt is set when the user asked to delete the account.
+	// The account is purged once this time has passed.
+	DeletionScheduledAt *time.Time `gorm:"index"`
 }
@@ -2,13 +2,35 @@
 CREATE TABLE users (
                        id BIGINT PRIMARY KEY AUTO_INCREMENT,
                        username VARCHAR(50) NOT NULL UNIQUE,
-                       password VARCHAR(100) NOT NULL,
+                       password VARCHAR(255) NOT NULL,
                        phone VARCHAR(20),
+                       phone_verified BOOLEAN DEFAULT FALSE,
                        address TEXT,
+                       nickname VARCHAR(50),
+                       email VARCHAR(254),
+                       gender VARCHAR(10),
+                       birthday DATE NULL,
+                       avatar_key VARCHAR(255),
+                       avatar_url VARCHAR(512),
+                       avatar_thumbnail_url VARCHAR(512),
                        points INT DEFAULT 0,
+                       tier VARCHAR(20),
+                       tier_since TIMESTAMP NULL,
+                       tier_review_at TIMESTAMP NULL,
+                       referral_code VARCHAR(16) UNIQUE,
+                       signup_device_id VARCHAR(64),
+                       totp_secret VARCHAR(255),
+                       totp_enabled BOOLEAN DEFAULT FALSE,
+                       role VARCHAR(20) NOT NULL DEFAULT 'customer',
+                       disabled BOOLEAN DEFAULT FALSE,
+                       disabled_reason VARCHAR(255),
+                       deletion_scheduled_at TIMESTAMP NULL,
                        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
                     updated_at TIMESTAMP ,
-                    deleted_at TIMESTAMP
+                    deleted_at TIMESTAMP,
+                    INDEX idx_users_phone (phone),
+                    INDEX idx_users_deletion_scheduled_at (deletion_scheduled_at),
+                    INDEX idx_users_tier_review_at (tier_review_at)
 );
 
 -- user_service_db.addresses
@@ -18,9 +40,110 @@ CREATE TABLE addresses (
                            receiver_name VARCHAR(50) NOT NULL,
                            phone VARCHAR(20) NOT NULL,
                            address_detail TEXT NOT NULL,
+                           province_code VARCHAR(6),
+                           province VARCHAR(50),
+                           city_code VARCHAR(6),
+                           city VARCHAR(50),
+                           district_code VARCHAR(6),
+                           district VARCHAR(50),
+                           street VARCHAR(255),
+                           building VARCHAR(255),
+                           postcode VARCHAR(6),
+                           latitude DOUBLE NULL,
+                           longitude DOUBLE NULL,
                            is_default BOOLEAN DEFAULT FALSE,
                            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
                            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
                            deleted_at TIMESTAMP,
+                           INDEX idx_addresses_city_code (city_code),
                            FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- user_service_db.recovery_codes
+CREATE TABLE recovery_codes (
+                                id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                                user_id BIGINT NOT NULL,
+                                code_hash VARCHAR(255) NOT NULL,
+                                used_at TIMESTAMP NULL,
+                                created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+                                deleted_at TIMESTAMP,
+                                INDEX idx_recovery_codes_user_id (user_id),
+                                FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- user_service_db.sessions
+CREATE TABLE sessions (
+                          id VARCHAR(32) PRIMARY KEY,
+                          user_id BIGINT NOT NULL,
+                          device_name VARCHAR(100),
+                          user_agent VARCHAR(255),
+                          ip VARCHAR(45),
+                          token_id VARCHAR(32),
+                          created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                          last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                          revoked_at TIMESTAMP NULL,
+                          INDEX idx_sessions_user_id (user_id),
+                          FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- user_service_db.points_transactions
+CREATE TABLE points_transactions (
+                                     id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                                     user_id BIGINT NOT NULL,
+                                     type VARCHAR(20) NOT NULL,
+                                     delta INT NOT NULL,
+                                     reason VARCHAR(255),
+                                     reference_id VARCHAR(64),
+                                     balance_after INT NOT NULL,
+                                     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                     INDEX idx_points_transactions_user_id (user_id),
+                                     UNIQUE INDEX idx_points_transactions_reference (user_id, type, reference_id),
+                                     FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- user_service_db.tier_changes
+CREATE TABLE tier_changes (
+                              id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                              user_id BIGINT NOT NULL,
+                              from_tier VARCHAR(20),
+                              to_tier VARCHAR(20) NOT NULL,
+                              lifetime_points INT NOT NULL,
+                              created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                              INDEX idx_tier_changes_user_id (user_id),
+                              FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- user_service_db.points_lots
+CREATE TABLE points_lots (
+                             id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                             user_id BIGINT NOT NULL,
+                             transaction_id BIGINT NOT NULL,
+                             amount INT NOT NULL,
+                             remaining INT NOT NULL,
+                             expires_at TIMESTAMP NULL,
+                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                             INDEX idx_points_lots_user_expiry (user_id, expires_at),
+                             INDEX idx_points_lots_expires_at (expires_at),
+                             FOREIGN KEY (user_id) REFERENCES users(id),
+                             FOREIGN KEY (transaction_id) REFERENCES points_transactions(id)
+);
+
+-- user_service_db.referrals
+CREATE TABLE referrals (
+                           id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                           inviter_id BIGINT NOT NULL,
+                           invitee_id BIGINT NOT NULL UNIQUE,
+                           status VARCHAR(20) NOT NULL,
+                           reject_reason VARCHAR(50),
+                           phone VARCHAR(20),
+                           device_id VARCHAR(64),
+                           order_id VARCHAR(64),
+                           created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                           rewarded_at TIMESTAMP NULL,
+                           INDEX idx_referrals_inviter_id (inviter_id),
+                           INDEX idx_referrals_phone (phone),
+                           INDEX idx_referrals_device_id (device_id),
+                           FOREIGN KEY (inviter_id) REFERENCES users(id),
+                           FOREIGN KEY (invitee_id) REFERENCES users(id)
 );
\ No newline at end of file
@@ -0,0 +1,308 @@
+package paging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxFilterLength bounds the work spent on parsing a filter.
+const maxFilterLength = 1000
+
+// parseFilter translates an AIP-160 filter into a SQL condition. The
+// supported subset is:
+//
+//	expression  = sequence { "AND" sequence }
+//	sequence    = factor { factor }            // implicit AND
+//	factor      = term { "OR" term }
+//	term        = [ "NOT" | "-" ] simple
+//	simple      = restriction | "(" expression ")"
+//	restriction = field ( "=" | "!=" | "<" | "<=" | ">" | ">=" | ":" ) value
+//
+// As in AIP-160, OR binds tighter than AND. Values are bare words or double
+// quoted strings. On string fields, ":" matches substrings and a trailing
+// "*" in an "=" value matches prefixes.
+func (s *Spec) parseFilter(filter string) (string, []interface{}, error) {
+	if strings.TrimSpace(filter) == "" {
+		return "", nil, nil
+	}
+	if len(filter) > maxFilterLength {
+		return "", nil, fmt.Errorf("%w: filter must be at most %d characters", ErrInvalid, maxFilterLength)
+	}
+	tokens, err := lex(filter)
+	if err != nil {
+		return "", nil, err
+	}
+	p := &parser{spec: s, tokens: tokens}
+	where, err := p.expression()
+	if err != nil {
+		return "", nil, err
+	}
+	if !p.done() {
+		return "", nil, fmt.Errorf("%w: unexpected %q in filter", ErrInvalid, p.peek().text)
+	}
+	return where, p.args, nil
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokMinus
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+}
+
+func lex(s string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, filterToken{tokLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, filterToken{tokRParen, ")"})
+			i++
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j == len(s) {
+				return nil, fmt.Errorf("%w: unterminated string in filter", ErrInvalid)
+			}
+			tokens = append(tokens, filterToken{tokString, b.String()})
+			i = j + 1
+		case strings.ContainsRune("=!<>:", rune(c)):
+			op := string(c)
+			if i+1 < len(s) && s[i+1] == '=' && c != '=' && c != ':' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("%w: unexpected \"!\" in filter", ErrInvalid)
+			}
+			tokens = append(tokens, filterToken{tokOp, op})
+			i += len(op)
+		case c == '-' && (len(tokens) == 0 || tokens[len(tokens)-1].kind != tokOp):
+			// A minus after a comparator is the sign of a number.
+			tokens = append(tokens, filterToken{tokMinus, "-"})
+			i++
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\r()\"=!<>:", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, filterToken{tokWord, s[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	spec   *Spec
+	tokens []filterToken
+	pos    int
+	args   []interface{}
+}
+
+func (p *parser) done() bool { return p.pos == len(p.tokens) }
+
+func (p *parser) peek() filterToken { return p.tokens[p.pos] }
+
+func (p *parser) keyword(word string) bool {
+	if !p.done() && p.peek().kind == tokWord && p.peek().text == word {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expression() (string, error) {
+	parts := []string{}
+	for {
+		seq, err := p.sequence()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, seq)
+		if !p.keyword("AND") {
+			break
+		}
+	}
+	return join(parts, " AND "), nil
+}
+
+func (p *parser) sequence() (string, error) {
+	parts := []string{}
+	for {
+		f, err := p.factor()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, f)
+		if p.done() || p.peek().kind == tokRParen || (p.peek().kind == tokWord && p.peek().text == "AND") {
+			break
+		}
+	}
+	return join(parts, " AND "), nil
+}
+
+func (p *parser) factor() (string, error) {
+	parts := []string{}
+	for {
+		t, err := p.term()
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, t)
+		if !p.keyword("OR") {
+			break
+		}
+	}
+	return join(parts, " OR "), nil
+}
+
+func (p *parser) term() (string, error) {
+	negate := p.keyword("NOT")
+	if !negate && !p.done() && p.peek().kind == tokMinus {
+		p.pos++
+		negate = true
+	}
+	s, err := p.simple()
+	if err != nil {
+		return "", err
+	}
+	if negate {
+		return "NOT " + s, nil
+	}
+	return s, nil
+}
+
+func (p *parser) simple() (string, error) {
+	if p.done() {
+		return "", fmt.Errorf("%w: filter ends unexpectedly", ErrInvalid)
+	}
+	if p.peek().kind == tokLParen {
+		p.pos++
+		s, err := p.expression()
+		if err != nil {
+			return "", err
+		}
+		if p.done() || p.peek().kind != tokRParen {
+			return "", fmt.Errorf("%w: missing \")\" in filter", ErrInvalid)
+		}
+		p.pos++
+		return "(" + s + ")", nil
+	}
+	return p.restriction()
+}
+
+func (p *parser) restriction() (string, error) {
+	name := p.peek()
+	if name.kind != tokWord {
+		return "", fmt.Errorf("%w: unexpected %q in filter", ErrInvalid, name.text)
+	}
+	p.pos++
+	field, ok := p.spec.Fields[name.text]
+	if !ok {
+		return "", fmt.Errorf("%w: cannot filter on %q", ErrInvalid, name.text)
+	}
+	if p.done() || p.peek().kind != tokOp {
+		return "", fmt.Errorf("%w: %q must be followed by a comparator", ErrInvalid, name.text)
+	}
+	op := p.peek().text
+	p.pos++
+	if p.done() || (p.peek().kind != tokWord && p.peek().kind != tokString) {
+		return "", fmt.Errorf("%w: %q %s needs a value", ErrInvalid, name.text, op)
+	}
+	value := p.peek()
+	p.pos++
+
+	if field.Kind == String {
+		return p.stringRestriction(name.text, field, op, value.text)
+	}
+	if op == ":" || (field.Kind == Bool && op != "=" && op != "!=") {
+		return "", fmt.Errorf("%w: %q does not support %s", ErrInvalid, name.text, op)
+	}
+	arg, err := parseValue(field.Kind, value.text)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid value %q for %q", ErrInvalid, value.text, name.text)
+	}
+	p.args = append(p.args, arg)
+	return fmt.Sprintf("%s %s ?", field.Column, sqlOp(op)), nil
+}
+
+func (p *parser) stringRestriction(name string, field Field, op, value string) (string, error) {
+	escape := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	switch {
+	case op == ":":
+		p.args = append(p.args, "%"+escape.Replace(value)+"%")
+		return field.Column + " LIKE ?", nil
+	case (op == "=" || op == "!=") && strings.HasSuffix(value, "*"):
+		p.args = append(p.args, escape.Replace(strings.TrimSuffix(value, "*"))+"%")
+		if op == "!=" {
+			return field.Column + " NOT LIKE ?", nil
+		}
+		return field.Column + " LIKE ?", nil
+	case strings.Contains(value, "*"):
+		return "", fmt.Errorf("%w: wildcards are only supported at the end of values of %q", ErrInvalid, name)
+	}
+	p.args = append(p.args, value)
+	return fmt.Sprintf("%s %s ?", field.Column, sqlOp(op)), nil
+}
+
+func sqlOp(op string) string {
+	if op == "!=" {
+		return "<>"
+	}
+	return op
+}
+
+func parseValue(kind Kind, s string) (interface{}, error) {
+	switch kind {
+	case Int:
+		return strconv.ParseInt(s, 10, 64)
+	case Bool:
+		return strconv.ParseBool(s)
+	case Time:
+		if isDigits(s) {
+			sec, err := strconv.ParseInt(s, 10, 64)
+			return time.Unix(sec, 0), err
+		}
+		return time.Parse(time.RFC3339, s)
+	}
+	return s, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func join(parts []string, sep string) string {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return "(" + strings.Join(parts, sep) + ")"
+}
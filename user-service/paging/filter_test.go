@@ -0,0 +1,143 @@
+package paging
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var testSpec = &Spec{
+	Fields: map[string]Field{
+		"id":         {Column: "id", Kind: Int, Sortable: true},
+		"name":       {Column: "name", Kind: String, Sortable: true},
+		"points":     {Column: "points", Kind: Int, Sortable: true},
+		"disabled":   {Column: "disabled", Kind: Bool},
+		"created_at": {Column: "created_at", Kind: Time, Sortable: true},
+		"note":       {Column: "note", Kind: String},
+	},
+	Key:             "id",
+	DefaultOrder:    "id",
+	DefaultPageSize: 2,
+	MaxPageSize:     3,
+}
+
+func TestParseFilter(t *testing.T) {
+	for _, tc := range []struct {
+		filter string
+		where  string
+		args   string
+	}{
+		{"", "", "[]"},
+		{"   ", "", "[]"},
+		{`name = "alice"`, "name = ?", "[alice]"},
+		{`name != bob`, "name <> ?", "[bob]"},
+		{`points >= 10`, "points >= ?", "[10]"},
+		{`points > -5`, "points > ?", "[-5]"},
+		{`disabled = true`, "disabled = ?", "[true]"},
+		{`name : "li"`, "name LIKE ?", "[%li%]"},
+		{`name = "al*"`, "name LIKE ?", "[al%]"},
+		{`name != "al*"`, "name NOT LIKE ?", "[al%]"},
+		{`note : "50%_off"`, "note LIKE ?", `[%50\%\_off%]`},
+		{`name = "say \"hi\""`, "name = ?", `[say "hi"]`},
+		{`points > 1 points < 9`, "(points > ? AND points < ?)", "[1 9]"},
+		{`points > 1 AND points < 9`, "(points > ? AND points < ?)", "[1 9]"},
+		// OR binds tighter than AND.
+		{`name = a OR name = b AND points > 1`, "((name = ? OR name = ?) AND points > ?)", "[a b 1]"},
+		{`name = a OR (name = b AND points > 1)`, "(name = ? OR ((name = ? AND points > ?)))", "[a b 1]"},
+		{`NOT disabled = true`, "NOT disabled = ?", "[true]"},
+		{`-name = a`, "NOT name = ?", "[a]"},
+	} {
+		where, args, err := testSpec.parseFilter(tc.filter)
+		if err != nil {
+			t.Errorf("%s: %v", tc.filter, err)
+			continue
+		}
+		if where != tc.where || fmt.Sprint(args) != tc.args {
+			t.Errorf("%s: got %q %v, want %q %s", tc.filter, where, args, tc.where, tc.args)
+		}
+	}
+
+	where, args, err := testSpec.parseFilter(`created_at > 1700000000 AND created_at < "2024-01-01T00:00:00Z"`)
+	if err != nil || where != "(created_at > ? AND created_at < ?)" {
+		t.Fatalf("times: %q, %v", where, err)
+	}
+	if !args[0].(time.Time).Equal(time.Unix(1700000000, 0)) || !args[1].(time.Time).Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("time arguments %v", args)
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	long := make([]byte, maxFilterLength+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+	for _, filter := range []string{
+		`password = "x"`,
+		`name`,
+		`name =`,
+		`name = "open`,
+		`(name = a`,
+		`name = a)`,
+		`name ! a`,
+		`points = ten`,
+		`points : 1`,
+		`disabled > true`,
+		`disabled = maybe`,
+		`created_at > yesterday`,
+		`name = "*al"`,
+		`AND name = a`,
+		`name = a OR`,
+		`= a`,
+		string(long),
+	} {
+		if _, _, err := testSpec.parseFilter(filter); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: got %v, want ErrInvalid", filter, err)
+		}
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	for _, tc := range []struct {
+		orderBy string
+		want    string
+	}{
+		{"id", "[id asc]"},
+		{"points desc", "[points desc id desc]"},
+		{"points DESC, name", "[points desc name asc id asc]"},
+		{" name asc ,  id desc ", "[name asc id desc]"},
+		{"created_at desc, id", "[created_at desc id asc]"},
+	} {
+		order, err := testSpec.parseOrder(tc.orderBy)
+		if err != nil {
+			t.Errorf("%s: %v", tc.orderBy, err)
+			continue
+		}
+		var got []string
+		for _, o := range order {
+			dir := "asc"
+			if o.desc {
+				dir = "desc"
+			}
+			got = append(got, o.name+" "+dir)
+		}
+		if fmt.Sprint(got) != tc.want {
+			t.Errorf("%s: got %v, want %s", tc.orderBy, got, tc.want)
+		}
+	}
+
+	for _, orderBy := range []string{
+		"",
+		"note",
+		"disabled",
+		"secret",
+		"name sideways",
+		"name asc desc",
+		"name,",
+		"name, name desc",
+	} {
+		if _, err := testSpec.parseOrder(orderBy); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q: got %v, want ErrInvalid", orderBy, err)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// Package paging implements the list convention shared by all UserService
+// list methods: page_size, an opaque page_token, an AIP-160 filter and an
+// AIP-132 order_by. Pages are read with keyset pagination, so a page costs
+// the same however deep the client pages, and rows inserted or deleted
+// meanwhile neither repeat nor skip rows of later pages.
+package paging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalid is returned for malformed page tokens, filters and orders. Its
+// message is meant for the client.
+var ErrInvalid = errors.New("invalid list request")
+
+// Kind is the type of a field.
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Bool
+	// Time fields accept RFC 3339 strings and Unix seconds in filters.
+	Time
+)
+
+// Field is a field of a list that clients may filter on.
+type Field struct {
+	Column string
+	Kind   Kind
+	// Sortable fields may be used in order_by. Their column must be NOT
+	// NULL, as the keyset comparisons never match NULL.
+	Sortable bool
+}
+
+// Spec describes one list method.
+type Spec struct {
+	// Fields maps the field names used in filter and order_by, which are
+	// those of the proto message, to columns.
+	Fields map[string]Field
+	// Key is a sortable field that is unique per row, usually "id". It is
+	// added to every order to make it total.
+	Key string
+	// DefaultOrder is used when the request has no order_by.
+	DefaultOrder    string
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+// Request holds the list parameters of a request.
+type Request struct {
+	PageSize  int32
+	PageToken string
+	Filter    string
+	OrderBy   string
+	// Scope describes conditions the caller added to the query that may
+	// differ between requests, such as a search term. Page tokens are tied
+	// to it like to the filter.
+	Scope string
+}
+
+type orderTerm struct {
+	name  string
+	field Field
+	desc  bool
+}
+
+// token is the decoded page token: the sort values of the last row of the
+// previous page and a checksum of the filter and order they belong to.
+type token struct {
+	Checksum string            `json:"c"`
+	After    []json.RawMessage `json:"a"`
+}
+
+// Find loads the page of query selected by req, ordered and filtered as
+// requested, and returns the page token of the next page, which is empty on
+// the last page. query carries the conditions that scope the list, e.g. to
+// the caller.
+func Find[T any](query *gorm.DB, spec *Spec, req Request) ([]T, string, error) {
+	pageSize := int(req.PageSize)
+	if pageSize < 0 {
+		return nil, "", fmt.Errorf("%w: page_size must not be negative", ErrInvalid)
+	}
+	if pageSize == 0 {
+		pageSize = spec.DefaultPageSize
+	}
+	if pageSize > spec.MaxPageSize {
+		pageSize = spec.MaxPageSize
+	}
+
+	orderBy := req.OrderBy
+	if strings.TrimSpace(orderBy) == "" {
+		orderBy = spec.DefaultOrder
+	}
+	order, err := spec.parseOrder(orderBy)
+	if err != nil {
+		return nil, "", err
+	}
+	if where, args, err := spec.parseFilter(req.Filter); err != nil {
+		return nil, "", err
+	} else if where != "" {
+		query = query.Where(where, args...)
+	}
+
+	checksum := checksum(req.Filter, req.Scope, order)
+	if req.PageToken != "" {
+		after, err := decodeToken(req.PageToken, checksum, order)
+		if err != nil {
+			return nil, "", err
+		}
+		where, args := keyset(order, after)
+		query = query.Where(where, args...)
+	}
+	for _, t := range order {
+		column := t.field.Column
+		if t.desc {
+			column += " DESC"
+		}
+		query = query.Order(column)
+	}
+
+	var rows []T
+	tx := query.Limit(pageSize + 1).Find(&rows)
+	if tx.Error != nil {
+		return nil, "", tx.Error
+	}
+	if len(rows) <= pageSize {
+		return rows, "", nil
+	}
+	rows = rows[:pageSize]
+	next, err := encodeToken(tx, rows[pageSize-1], checksum, order)
+	if err != nil {
+		return nil, "", err
+	}
+	return rows, next, nil
+}
+
+// parseOrder parses an order_by such as "created_at desc, id" and appends
+// the key field unless it is already part of the order.
+func (s *Spec) parseOrder(orderBy string) ([]orderTerm, error) {
+	var order []orderTerm
+	seen := map[string]bool{}
+	for _, part := range strings.Split(orderBy, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, fmt.Errorf("%w: malformed order_by %q", ErrInvalid, orderBy)
+		}
+		field, ok := s.Fields[words[0]]
+		if !ok || !field.Sortable {
+			return nil, fmt.Errorf("%w: cannot order by %q", ErrInvalid, words[0])
+		}
+		if seen[words[0]] {
+			return nil, fmt.Errorf("%w: %q appears twice in order_by", ErrInvalid, words[0])
+		}
+		seen[words[0]] = true
+		t := orderTerm{name: words[0], field: field}
+		if len(words) == 2 {
+			switch strings.ToLower(words[1]) {
+			case "asc":
+			case "desc":
+				t.desc = true
+			default:
+				return nil, fmt.Errorf("%w: malformed order_by %q", ErrInvalid, orderBy)
+			}
+		}
+		order = append(order, t)
+	}
+	if !seen[s.Key] {
+		order = append(order, orderTerm{name: s.Key, field: s.Fields[s.Key], desc: order[len(order)-1].desc})
+	}
+	return order, nil
+}
+
+// keyset returns the condition selecting the rows after the row with the
+// sort values after, i.e. for order (a, b DESC):
+// a > ? OR (a = ? AND b < ?).
+func keyset(order []orderTerm, after []interface{}) (string, []interface{}) {
+	var ors []string
+	var args []interface{}
+	for i, t := range order {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, order[j].field.Column+" = ?")
+			args = append(args, after[j])
+		}
+		op := " > ?"
+		if t.desc {
+			op = " < ?"
+		}
+		ands = append(ands, t.field.Column+op)
+		args = append(args, after[i])
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+// checksum ties a page token to the filter, scope and order it was issued
+// for, so a token is not used with different parameters.
+func checksum(filter, scope string, order []orderTerm) string {
+	h := fnv.New64a()
+	h.Write([]byte(strings.TrimSpace(filter)))
+	if scope != "" {
+		fmt.Fprintf(h, "\x00scope %q", scope)
+	}
+	for _, t := range order {
+		fmt.Fprintf(h, "\x00%s %t", t.name, t.desc)
+	}
+	return strconv.FormatUint(h.Sum64(), 36)
+}
+
+func encodeToken(tx *gorm.DB, row interface{}, checksum string, order []orderTerm) (string, error) {
+	if tx.Statement.Schema == nil {
+		return "", errors.New("paging: unknown schema")
+	}
+	t := token{Checksum: checksum}
+	rv := reflect.ValueOf(row)
+	for _, o := range order {
+		field := tx.Statement.Schema.LookUpField(o.field.Column)
+		if field == nil {
+			return "", fmt.Errorf("paging: no column %s in %s", o.field.Column, tx.Statement.Schema.Name)
+		}
+		v, _ := field.ValueOf(tx.Statement.Context, rv)
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return "", fmt.Errorf("paging: sort column %s is NULL", o.field.Column)
+			}
+			v = rv.Elem().Interface()
+		}
+		if tm, ok := v.(time.Time); ok {
+			v = tm.UTC().Format(time.RFC3339Nano)
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		t.After = append(t.After, raw)
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeToken(s, checksum string, order []orderTerm) ([]interface{}, error) {
+	invalid := fmt.Errorf("%w: invalid page_token", ErrInvalid)
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalid
+	}
+	var t token
+	if err := json.Unmarshal(b, &t); err != nil || len(t.After) != len(order) {
+		return nil, invalid
+	}
+	if t.Checksum != checksum {
+		return nil, fmt.Errorf("%w: page_token was issued for a different filter or order_by", ErrInvalid)
+	}
+	after := make([]interface{}, len(order))
+	for i, o := range order {
+		var err error
+		switch o.field.Kind {
+		case String:
+			var v string
+			err = json.Unmarshal(t.After[i], &v)
+			after[i] = v
+		case Int:
+			var v int64
+			err = json.Unmarshal(t.After[i], &v)
+			after[i] = v
+		case Bool:
+			var v bool
+			err = json.Unmarshal(t.After[i], &v)
+			after[i] = v
+		case Time:
+			var v string
+			if err = json.Unmarshal(t.After[i], &v); err == nil {
+				after[i], err = time.Parse(time.RFC3339Nano, v)
+			}
+		}
+		if err != nil {
+			return nil, invalid
+		}
+	}
+	return after, nil
+}
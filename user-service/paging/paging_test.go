@@ -0,0 +1,154 @@
+package paging
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type item struct {
+	ID        uint `gorm:"primaryKey"`
+	Name      string
+	Points    int
+	Disabled  bool
+	Note      string
+	CreatedAt time.Time
+}
+
+func openItems(t *testing.T, n int) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "paging.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if err := db.AutoMigrate(&item{}); err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= n; i++ {
+		// Points repeat so the key has to break ties.
+		it := item{Name: fmt.Sprintf("item%d", i), Points: i % 3, Disabled: i%4 == 0, CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := db.Create(&it).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+// pageAll follows the page tokens and returns the ids of all pages.
+func pageAll(t *testing.T, db *gorm.DB, req Request) ([]uint, int) {
+	t.Helper()
+	var ids []uint
+	pages := 0
+	for {
+		items, next, err := Find[item](db.Model(&item{}), testSpec, req)
+		if err != nil {
+			t.Fatalf("page %d of %+v: %v", pages, req, err)
+		}
+		pages++
+		for _, it := range items {
+			ids = append(ids, it.ID)
+		}
+		if next == "" {
+			return ids, pages
+		}
+		if pages > 20 {
+			t.Fatal("paging does not end")
+		}
+		req.PageToken = next
+	}
+}
+
+func TestFindPages(t *testing.T) {
+	db := openItems(t, 7)
+	for _, tc := range []struct {
+		req   Request
+		ids   string
+		pages int
+	}{
+		{Request{}, "[1 2 3 4 5 6 7]", 4},
+		{Request{PageSize: 3}, "[1 2 3 4 5 6 7]", 3},
+		// page_size is capped at MaxPageSize.
+		{Request{PageSize: 100}, "[1 2 3 4 5 6 7]", 3},
+		{Request{OrderBy: "points desc"}, "[5 2 7 4 1 6 3]", 4},
+		{Request{OrderBy: "points, id desc"}, "[6 3 7 4 1 5 2]", 4},
+		{Request{OrderBy: "created_at desc"}, "[7 6 5 4 3 2 1]", 4},
+		{Request{OrderBy: "name desc"}, "[7 6 5 4 3 2 1]", 4},
+		{Request{Filter: "disabled = false AND points > 0", OrderBy: "points desc"}, "[5 2 7 1]", 2},
+		{Request{Filter: `name = "nothing*"`}, "[]", 1},
+	} {
+		ids, pages := pageAll(t, db, tc.req)
+		if fmt.Sprint(ids) != tc.ids || pages != tc.pages {
+			t.Errorf("%+v: got %v in %d pages, want %s in %d", tc.req, ids, pages, tc.ids, tc.pages)
+		}
+	}
+}
+
+func TestFindTokenIsStable(t *testing.T) {
+	db := openItems(t, 6)
+	req := Request{PageSize: 2, OrderBy: "points desc"}
+	first, next, err := Find[item](db.Model(&item{}), testSpec, req)
+	if err != nil || len(first) != 2 {
+		t.Fatal(err)
+	}
+	// The first page was [5 2]. A row inserted before the cursor does not
+	// shift the later pages, one inserted after it shows up in place.
+	for _, points := range []int{2, 0} {
+		if err := db.Create(&item{Name: "late", Points: points, CreatedAt: time.Now()}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	req.PageToken = next
+	ids, _ := pageAll(t, db, req)
+	if fmt.Sprint(ids) != "[4 1 8 6 3]" {
+		t.Errorf("after an insert: got %v", ids)
+	}
+}
+
+func TestFindTokenMismatch(t *testing.T) {
+	db := openItems(t, 5)
+	req := Request{PageSize: 2, Filter: "points > 0", OrderBy: "points desc"}
+	_, token, err := Find[item](db.Model(&item{}), testSpec, req)
+	if err != nil || token == "" {
+		t.Fatalf("first page: %q, %v", token, err)
+	}
+
+	for name, changed := range map[string]Request{
+		"other filter":    {PageSize: 2, Filter: "points > 1", OrderBy: "points desc"},
+		"no filter":       {PageSize: 2, OrderBy: "points desc"},
+		"other order":     {PageSize: 2, Filter: "points > 0", OrderBy: "points"},
+		"other field":     {PageSize: 2, Filter: "points > 0", OrderBy: "name desc"},
+		"default order":   {PageSize: 2, Filter: "points > 0"},
+		"garbage token":   {PageSize: 2, Filter: "points > 0", OrderBy: "points desc", PageToken: "not a token"},
+		"truncated token": {PageSize: 2, Filter: "points > 0", OrderBy: "points desc", PageToken: token[:len(token)/2]},
+	} {
+		if changed.PageToken == "" {
+			changed.PageToken = token
+		}
+		if _, _, err := Find[item](db.Model(&item{}), testSpec, changed); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: got %v, want ErrInvalid", name, err)
+		}
+	}
+
+	// The page size may change between pages, and surrounding whitespace of
+	// the filter does not matter.
+	req.PageSize, req.Filter, req.PageToken = 3, " points > 0 ", token
+	if _, _, err := Find[item](db.Model(&item{}), testSpec, req); err != nil {
+		t.Errorf("same filter and order: %v", err)
+	}
+
+	if _, _, err := Find[item](db.Model(&item{}), testSpec, Request{PageSize: -1}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("negative page size: got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package points
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// grant records the points of a ledger entry as a new lot.
+func grant(tx *gorm.DB, entry *model.PointsTransaction) error {
+	lot := &model.PointsLot{
+		UserID:        entry.UserID,
+		TransactionID: entry.ID,
+		Amount:        entry.Delta,
+		Remaining:     entry.Delta,
+	}
+	if config.PointsLifetime > 0 {
+		expiresAt := entry.CreatedAt.Add(config.PointsLifetime)
+		lot.ExpiresAt = &expiresAt
+	}
+	return tx.Create(lot).Error
+}
+
+// consume takes amount points out of the user's lots, the ones expiring
+// first before the others. Points not tracked by any lot are the oldest and
+// go first. The caller must hold the lock on the user row.
+func consume(tx *gorm.DB, userID uint, amount, balanceBefore int) error {
+	var tracked int
+	err := tx.Model(&model.PointsLot{}).
+		Where("user_id = ? AND remaining > 0", userID).
+		Select("COALESCE(SUM(remaining), 0)").Scan(&tracked).Error
+	if err != nil {
+		return err
+	}
+	amount -= min(max(balanceBefore-tracked, 0), amount)
+	if amount == 0 {
+		return nil
+	}
+
+	// Lots that never expire sort last.
+	var lots []model.PointsLot
+	err = tx.Where("user_id = ? AND remaining > 0", userID).
+		Order("expires_at IS NULL, expires_at, id").
+		Find(&lots).Error
+	if err != nil {
+		return err
+	}
+	for _, lot := range lots {
+		take := min(lot.Remaining, amount)
+		if err := tx.Model(&lot).Update("remaining", lot.Remaining-take).Error; err != nil {
+			return err
+		}
+		if amount -= take; amount == 0 {
+			return nil
+		}
+	}
+	return errors.New("points lots do not cover the balance")
+}
+
+// Expire removes the points of the user's lots that expired by now and
+// records them in the ledger. It returns the ledger entry, or nil when
+// nothing expired.
+func Expire(ctx context.Context, userID uint, now time.Time) (*model.PointsTransaction, error) {
+	var entry *model.PointsTransaction
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user model.User
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "points").First(&user, userID).Error
+		if err != nil {
+			return err
+		}
+
+		var lots []model.PointsLot
+		err = tx.Where("user_id = ? AND remaining > 0 AND expires_at <= ?", userID, now).Find(&lots).Error
+		if err != nil || len(lots) == 0 {
+			return err
+		}
+		var ids []uint
+		expired := 0
+		for _, lot := range lots {
+			ids = append(ids, lot.ID)
+			expired += lot.Remaining
+		}
+		if err := tx.Model(&model.PointsLot{}).Where("id IN ?", ids).Update("remaining", 0).Error; err != nil {
+			return err
+		}
+		// Lots never exceed the balance, the bound only guards against
+		// manual edits of the database.
+		expired = min(expired, user.Points)
+		if expired == 0 {
+			return nil
+		}
+		if err := tx.Model(&user).Update("points", gorm.Expr("points - ?", expired)).Error; err != nil {
+			return err
+		}
+		entry = &model.PointsTransaction{
+			UserID:       userID,
+			Type:         model.PointsExpire,
+			Delta:        -expired,
+			Reason:       "Points expired",
+			BalanceAfter: user.Points - expired,
+		}
+		return tx.Create(entry).Error
+	})
+	return entry, err
+}
+
+// Expiring returns the user's lots with points left that expire before the
+// given time, the ones expiring first first.
+func Expiring(ctx context.Context, userID uint, before time.Time) ([]model.PointsLot, error) {
+	var lots []model.PointsLot
+	err := config.DB.WithContext(ctx).
+		Where("user_id = ? AND remaining > 0 AND expires_at <= ?", userID, before).
+		Order("expires_at, id").
+		Find(&lots).Error
+	return lots, err
+}
@@ -0,0 +1,130 @@
+package points
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+)
+
+func lots(t *testing.T, userID uint) []model.PointsLot {
+	t.Helper()
+	var lots []model.PointsLot
+	if err := config.DB.Where("user_id = ?", userID).Order("id").Find(&lots).Error; err != nil {
+		t.Fatal(err)
+	}
+	return lots
+}
+
+func remaining(lots []model.PointsLot) []int {
+	r := make([]int, len(lots))
+	for i, lot := range lots {
+		r[i] = lot.Remaining
+	}
+	return r
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// earn grants amount points that expire at expiresAt, or never if it is
+// zero.
+func earn(t *testing.T, userID uint, amount int, expiresAt time.Time) {
+	t.Helper()
+	entry, _, err := Apply(context.Background(), Change{UserID: userID, Type: model.PointsEarn, Delta: amount})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exp *time.Time
+	if !expiresAt.IsZero() {
+		exp = &expiresAt
+	}
+	if err := config.DB.Model(&model.PointsLot{}).Where("transaction_id = ?", entry.ID).Update("expires_at", exp).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRedeemAcrossLots(t *testing.T) {
+	// 20 points predate lots and are spent first.
+	user := newUser(t, 20)
+	now := time.Now()
+	earn(t, user.ID, 50, now.Add(30*24*time.Hour))
+	earn(t, user.ID, 30, now.Add(10*24*time.Hour))
+	earn(t, user.ID, 40, time.Time{})
+	ctx := context.Background()
+
+	for _, step := range []struct {
+		redeem int
+		want   []int
+	}{
+		{15, []int{50, 30, 40}},
+		// The lot expiring first goes first, even though it was granted later.
+		{25, []int{50, 10, 40}},
+		{35, []int{25, 0, 40}},
+		// Lots that never expire go last.
+		{40, []int{0, 0, 25}},
+	} {
+		if _, _, err := Apply(ctx, Change{UserID: user.ID, Type: model.PointsRedeem, Delta: -step.redeem}); err != nil {
+			t.Fatal(err)
+		}
+		if got := remaining(lots(t, user.ID)); !equal(got, step.want) {
+			t.Fatalf("after redeeming %d: remaining %v, want %v", step.redeem, got, step.want)
+		}
+	}
+	if got := balance(t, user.ID); got != 25 {
+		t.Fatalf("balance %d, want 25", got)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	user := newUser(t, 0)
+	now := time.Now()
+	earn(t, user.ID, 50, now.Add(-time.Hour))
+	earn(t, user.ID, 30, now.Add(time.Hour))
+	earn(t, user.ID, 20, time.Time{})
+	ctx := context.Background()
+
+	if _, _, err := Apply(ctx, Change{UserID: user.ID, Type: model.PointsRedeem, Delta: -10}); err != nil {
+		t.Fatal(err)
+	}
+
+	expiring, err := Expiring(ctx, user.ID, now.Add(2*time.Hour))
+	if err != nil || len(expiring) != 2 || expiring[0].Remaining != 40 || expiring[1].Remaining != 30 {
+		t.Fatalf("expiring lots %+v, %v", expiring, err)
+	}
+
+	entry, err := Expire(ctx, user.ID, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry == nil || entry.Type != model.PointsExpire || entry.Delta != -40 || entry.BalanceAfter != 50 {
+		t.Fatalf("expiry entry %+v", entry)
+	}
+	if got := balance(t, user.ID); got != 50 {
+		t.Fatalf("balance %d, want 50", got)
+	}
+	if got := remaining(lots(t, user.ID)); !equal(got, []int{0, 30, 20}) {
+		t.Fatalf("remaining %v", got)
+	}
+
+	// Expiring again finds nothing to do.
+	if entry, err := Expire(ctx, user.ID, now); err != nil || entry != nil {
+		t.Fatalf("second expiry: %+v, %v", entry, err)
+	}
+
+	entry, err = Expire(ctx, user.ID, now.Add(2*time.Hour))
+	if err != nil || entry == nil || entry.Delta != -30 || entry.BalanceAfter != 20 {
+		t.Fatalf("later expiry: %+v, %v", entry, err)
+	}
+}
@@ -0,0 +1,132 @@
+// Package points keeps user points balances and their ledger consistent.
+package points
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInsufficientPoints = errors.New("insufficient points")
+	ErrUserNotFound       = errors.New("user not found")
+	// ErrReferenceConflict means the reference ID was already used for a
+	// transaction of the same type with a different amount.
+	ErrReferenceConflict = errors.New("reference id was already used with a different amount")
+)
+
+// Change describes a change of a user's balance.
+type Change struct {
+	UserID uint
+	// Type is one of model.PointsEarn, PointsRedeem and PointsAdjust.
+	Type  string
+	Delta int
+	// ReferenceID makes the change idempotent: applying a change with a
+	// reference ID that was already applied returns the original entry.
+	ReferenceID string
+	Reason      string
+}
+
+// Apply changes the balance and appends the ledger entry in one transaction.
+// The balance never drops below zero, even under concurrent changes. The
+// returned bool is true when the change had already been applied before.
+func Apply(ctx context.Context, c Change) (*model.PointsTransaction, bool, error) {
+	if c.ReferenceID != "" {
+		if entry, err := findByReference(ctx, c); entry != nil || err != nil {
+			return entry, entry != nil, err
+		}
+	}
+
+	var entry *model.PointsTransaction
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		entry, err = apply(tx, c)
+		return err
+	})
+	if err != nil && c.ReferenceID != "" && !errors.Is(err, ErrInsufficientPoints) && !errors.Is(err, ErrUserNotFound) {
+		// A concurrent request with the same reference ID won the race and
+		// the unique index rejected this one.
+		if existing, findErr := findByReference(ctx, c); existing != nil {
+			return existing, true, nil
+		} else if findErr != nil {
+			return nil, false, findErr
+		}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return entry, false, nil
+}
+
+func apply(tx *gorm.DB, c Change) (*model.PointsTransaction, error) {
+	// The balance check is part of the UPDATE, so concurrent deductions
+	// cannot drive it below zero. The row stays locked until commit, which
+	// also serializes all changes to the user's lots.
+	res := tx.Model(&model.User{}).
+		Where("id = ? AND points + ? >= 0", c.UserID, c.Delta).
+		Update("points", gorm.Expr("points + ?", c.Delta))
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		var count int64
+		if err := tx.Model(&model.User{}).Where("id = ?", c.UserID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, ErrUserNotFound
+		}
+		return nil, ErrInsufficientPoints
+	}
+
+	var balance int
+	if err := tx.Model(&model.User{}).Where("id = ?", c.UserID).Pluck("points", &balance).Error; err != nil {
+		return nil, err
+	}
+	entry := &model.PointsTransaction{
+		UserID:       c.UserID,
+		Type:         c.Type,
+		Delta:        c.Delta,
+		Reason:       c.Reason,
+		BalanceAfter: balance,
+	}
+	if c.ReferenceID != "" {
+		entry.ReferenceID = &c.ReferenceID
+	}
+	if err := tx.Create(entry).Error; err != nil {
+		return nil, err
+	}
+
+	var err error
+	if c.Delta > 0 {
+		err = grant(tx, entry)
+	} else {
+		err = consume(tx, c.UserID, -c.Delta, balance-c.Delta)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// findByReference returns the entry already recorded for the reference ID
+// of c, or nil.
+func findByReference(ctx context.Context, c Change) (*model.PointsTransaction, error) {
+	var entry model.PointsTransaction
+	err := config.DB.WithContext(ctx).
+		Where("user_id = ? AND type = ? AND reference_id = ?", c.UserID, c.Type, c.ReferenceID).
+		First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.Delta != c.Delta {
+		return nil, ErrReferenceConflict
+	}
+	return &entry, nil
+}
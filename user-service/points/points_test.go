@@ -0,0 +1,110 @@
+package points
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/internal/testenv"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+)
+
+func newUser(t *testing.T, points int) *model.User {
+	t.Helper()
+	testenv.Setup(t)
+	config.PointsLifetime = 365 * 24 * time.Hour
+	user := &model.User{Username: "alice", Password: "x", Points: points}
+	if err := config.DB.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func balance(t *testing.T, userID uint) int {
+	t.Helper()
+	var user model.User
+	if err := config.DB.First(&user, userID).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user.Points
+}
+
+func TestApplyLedger(t *testing.T) {
+	user := newUser(t, 0)
+	ctx := context.Background()
+
+	steps := []struct {
+		name    string
+		change  Change
+		balance int
+		err     error
+	}{
+		{"earn", Change{Type: model.PointsEarn, Delta: 100, ReferenceID: "order-1"}, 100, nil},
+		{"redeem part", Change{Type: model.PointsRedeem, Delta: -30, ReferenceID: "order-2"}, 70, nil},
+		{"redeem too much", Change{Type: model.PointsRedeem, Delta: -71, ReferenceID: "order-3"}, 70, ErrInsufficientPoints},
+		{"refund", Change{Type: model.PointsAdjust, Delta: 30, Reason: "order-2 refunded"}, 100, nil},
+		{"redeem all", Change{Type: model.PointsRedeem, Delta: -100, ReferenceID: "order-4"}, 0, nil},
+	}
+	for _, step := range steps {
+		step.change.UserID = user.ID
+		entry, replayed, err := Apply(ctx, step.change)
+		if !errors.Is(err, step.err) {
+			t.Fatalf("%s: got %v, want %v", step.name, err, step.err)
+		}
+		if err == nil && (replayed || entry.BalanceAfter != step.balance || entry.Delta != step.change.Delta) {
+			t.Fatalf("%s: entry %+v, replayed %v", step.name, entry, replayed)
+		}
+		if got := balance(t, user.ID); got != step.balance {
+			t.Fatalf("%s: balance %d, want %d", step.name, got, step.balance)
+		}
+	}
+
+	var entries []model.PointsTransaction
+	if err := config.DB.Where("user_id = ?", user.ID).Order("id").Find(&entries).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("%d ledger entries, want 4", len(entries))
+	}
+	sum := 0
+	for _, e := range entries {
+		sum += e.Delta
+		if e.BalanceAfter != sum {
+			t.Fatalf("entry %d: balance after %d, running sum %d", e.ID, e.BalanceAfter, sum)
+		}
+	}
+
+	if _, _, err := Apply(ctx, Change{UserID: 999, Type: model.PointsEarn, Delta: 1}); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("unknown user: got %v", err)
+	}
+}
+
+func TestApplyIdempotent(t *testing.T) {
+	user := newUser(t, 0)
+	ctx := context.Background()
+	earn := Change{UserID: user.ID, Type: model.PointsEarn, Delta: 50, ReferenceID: "order-1"}
+
+	first, replayed, err := Apply(ctx, earn)
+	if err != nil || replayed {
+		t.Fatalf("first: %v, replayed %v", err, replayed)
+	}
+	again, replayed, err := Apply(ctx, earn)
+	if err != nil || !replayed || again.ID != first.ID {
+		t.Fatalf("retry: entry %+v, replayed %v, %v", again, replayed, err)
+	}
+	if got := balance(t, user.ID); got != 50 {
+		t.Fatalf("balance %d after a retry, want 50", got)
+	}
+
+	earn.Delta = 60
+	if _, _, err := Apply(ctx, earn); !errors.Is(err, ErrReferenceConflict) {
+		t.Fatalf("same reference, other amount: got %v", err)
+	}
+	// Reference IDs are scoped to the type.
+	redeem := Change{UserID: user.ID, Type: model.PointsRedeem, Delta: -50, ReferenceID: "order-1"}
+	if _, replayed, err := Apply(ctx, redeem); err != nil || replayed {
+		t.Fatalf("redeem with the reference of an earn: %v, replayed %v", err, replayed)
+	}
+}
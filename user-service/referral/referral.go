@@ -0,0 +1,155 @@
+// Package referral hands out referral codes and rewards users for the new
+// customers they invite.
+package referral
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"gorm.io/gorm"
+)
+
+// codeAlphabet leaves out characters that are easily confused, such as 0
+// and O.
+const (
+	codeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	codeLength   = 8
+	// maxCodeAttempts bounds the retries after a code collision.
+	maxCodeAttempts = 5
+)
+
+// Reasons a referral is rejected.
+const (
+	RejectSamePhone      = "same_phone"
+	RejectSameDevice     = "same_device"
+	RejectPhoneReferred  = "phone_already_referred"
+	RejectDeviceReferred = "device_already_referred"
+)
+
+var (
+	ErrInvalidCode = errors.New("invalid referral code")
+	ErrNotReferred = errors.New("user was not referred")
+)
+
+// NewCode returns a random referral code.
+func NewCode() (string, error) {
+	raw := make([]byte, codeLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	code := make([]byte, codeLength)
+	for i, b := range raw {
+		code[i] = codeAlphabet[int(b)%len(codeAlphabet)]
+	}
+	return string(code), nil
+}
+
+// NormalizeCode makes codes typed in by users comparable.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// EnsureCode returns the referral code of a user, creating one for accounts
+// that have none yet.
+func EnsureCode(ctx context.Context, userID uint) (string, error) {
+	db := config.DB.WithContext(ctx)
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		var user model.User
+		if err := db.Select("id", "referral_code").First(&user, userID).Error; err != nil {
+			return "", err
+		}
+		if user.ReferralCode != nil {
+			return *user.ReferralCode, nil
+		}
+		code, err := NewCode()
+		if err != nil {
+			return "", err
+		}
+		// A collision with another user's code fails on the unique index
+		// and is retried with a new code.
+		err = db.Model(&model.User{}).Where("id = ? AND referral_code IS NULL", userID).Update("referral_code", code).Error
+		if err == nil {
+			continue
+		}
+		if !utils.IsDuplicateEntry(err) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no unique referral code after %d attempts", maxCodeAttempts)
+}
+
+// FindInviter returns the active user owning the code.
+func FindInviter(ctx context.Context, code string) (*model.User, error) {
+	var inviter model.User
+	err := config.DB.WithContext(ctx).
+		Where("referral_code = ? AND disabled = ? AND deletion_scheduled_at IS NULL", NormalizeCode(code), false).
+		First(&inviter).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidCode
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inviter, nil
+}
+
+// Record links a newly created invitee to the inviter within the transaction
+// that created the invitee. Sign-ups that share a phone or device with the
+// inviter or an earlier referral are recorded as rejected and never earn
+// rewards.
+func Record(tx *gorm.DB, inviter, invitee *model.User) (*model.Referral, error) {
+	phone, _ := utils.NormalizePhone(invitee.Phone)
+	inviterPhone, _ := utils.NormalizePhone(inviter.Phone)
+	ref := &model.Referral{
+		InviterID: inviter.ID,
+		InviteeID: invitee.ID,
+		Status:    model.ReferralPending,
+		Phone:     phone,
+		DeviceID:  invitee.SignupDeviceID,
+	}
+
+	reason, err := abuseReason(tx, phone, inviterPhone, ref.DeviceID, inviter.SignupDeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		ref.Status = model.ReferralRejected
+		ref.RejectReason = reason
+	}
+	return ref, tx.Create(ref).Error
+}
+
+// abuseReason checks a sign-up for signs of inviting oneself.
+func abuseReason(tx *gorm.DB, phone, inviterPhone, deviceID, inviterDeviceID string) (string, error) {
+	switch {
+	case phone != "" && phone == inviterPhone:
+		return RejectSamePhone, nil
+	case deviceID != "" && deviceID == inviterDeviceID:
+		return RejectSameDevice, nil
+	}
+	checks := []struct {
+		column, value, reason string
+	}{
+		{"phone", phone, RejectPhoneReferred},
+		{"device_id", deviceID, RejectDeviceReferred},
+	}
+	for _, c := range checks {
+		if c.value == "" {
+			continue
+		}
+		var count int64
+		if err := tx.Model(&model.Referral{}).Where(c.column+" = ?", c.value).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count > 0 {
+			return c.reason, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,126 @@
+package referral
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/config"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/model"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/points"
+	"github.com/yinxi0607/YixiGroceryAPI/user-service/utils"
+	"gorm.io/gorm"
+)
+
+// maxStreamLength caps the reward stream.
+const maxStreamLength = 100000
+
+// Complete rewards both sides of the invitee's referral after the invitee's
+// first order. It is idempotent: repeated calls, also with other orders,
+// return the referral unchanged, and points are granted once even if an
+// earlier call failed half way.
+func Complete(ctx context.Context, inviteeID uint, orderID string) (*model.Referral, error) {
+	db := config.DB.WithContext(ctx)
+	var ref model.Referral
+	err := db.Where("invitee_id = ?", inviteeID).First(&ref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotReferred
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transitioned := false
+	if ref.Status == model.ReferralPending {
+		// The invitee may have changed the phone to the inviter's since
+		// signing up.
+		var users []model.User
+		if err := db.Select("id", "phone").Where("id IN ?", []uint{ref.InviterID, ref.InviteeID}).Find(&users).Error; err != nil {
+			return nil, err
+		}
+		updates := map[string]interface{}{"status": model.ReferralRewarded, "order_id": orderID, "rewarded_at": time.Now()}
+		if len(users) == 2 {
+			a, _ := utils.NormalizePhone(users[0].Phone)
+			b, _ := utils.NormalizePhone(users[1].Phone)
+			if a != "" && a == b {
+				updates = map[string]interface{}{"status": model.ReferralRejected, "reject_reason": RejectSamePhone}
+			}
+		}
+		// Only one of concurrent calls moves the referral on.
+		res := db.Model(&model.Referral{}).Where("id = ? AND status = ?", ref.ID, model.ReferralPending).Updates(updates)
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		transitioned = res.RowsAffected == 1
+		if err := db.First(&ref, ref.ID).Error; err != nil {
+			return nil, err
+		}
+	}
+	if ref.Status != model.ReferralRewarded {
+		return &ref, nil
+	}
+
+	reference := "referral-" + strconv.FormatUint(uint64(ref.ID), 10)
+	grants := []struct {
+		userID uint
+		amount int
+		reason string
+	}{
+		{ref.InviterID, config.ReferralInviterPoints, "Referral reward for inviting a friend"},
+		{ref.InviteeID, config.ReferralInviteePoints, "Referral reward for joining"},
+	}
+	for _, g := range grants {
+		if g.amount <= 0 {
+			continue
+		}
+		_, _, err := points.Apply(ctx, points.Change{
+			UserID:      g.userID,
+			Type:        model.PointsEarn,
+			Delta:       g.amount,
+			ReferenceID: reference,
+			Reason:      g.reason,
+		})
+		if errors.Is(err, points.ErrUserNotFound) {
+			// The account was deleted in the meantime.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("granting referral points to user %d: %w", g.userID, err)
+		}
+	}
+	if transitioned {
+		publish(ctx, &ref)
+	}
+	return &ref, nil
+}
+
+// publish emits a rewarded referral, so coupons can be issued.
+func publish(ctx context.Context, ref *model.Referral) {
+	log.Printf("Referral %d of user %d by user %d rewarded", ref.ID, ref.InviteeID, ref.InviterID)
+	if config.ReferralEventStream == "" {
+		return
+	}
+	err := config.RedisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: config.ReferralEventStream,
+		MaxLen: maxStreamLength,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type":           "referral_rewarded",
+			"referral_id":    strconv.FormatUint(uint64(ref.ID), 10),
+			"inviter_id":     strconv.FormatUint(uint64(ref.InviterID), 10),
+			"invitee_id":     strconv.FormatUint(uint64(ref.InviteeID), 10),
+			"order_id":       ref.OrderID,
+			"inviter_points": config.ReferralInviterPoints,
+			"invitee_points": config.ReferralInviteePoints,
+			"inviter_coupon": config.ReferralInviterCoupon,
+			"invitee_coupon": config.ReferralInviteeCoupon,
+		},
+	}).Err()
+	if err != nil {
+		log.Printf("Failed to publish referral reward %d: %v", ref.ID, err)
+	}
+}
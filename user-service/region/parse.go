@@ -0,0 +1,108 @@
+package region
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	postcodePattern = regexp.MustCompile(`(^|[^0-9])([0-9]{6})([^0-9]|$)`)
+	// streetPattern ends the street at the house number, e.g. 文三路90号.
+	streetPattern = regexp.MustCompile(`^(.*?(?:大道|路|街|道|巷|弄|胡同)(?:[0-9]+(?:-[0-9]+)?号)?)(.*)$`)
+)
+
+// Parsed is the result of ParseAddress.
+type Parsed struct {
+	Place
+	Street   string
+	Building string
+	Postcode string
+}
+
+// ParseAddress splits a free-text address such as
+// "浙江省杭州市西湖区文三路90号东部软件园3号楼" into its parts. It is best
+// effort: parts that cannot be recognized are left empty, and whatever
+// follows the last recognized region ends up in Street and Building.
+func (d *Dataset) ParseAddress(text string) Parsed {
+	var p Parsed
+	text = strings.TrimSpace(text)
+	if m := postcodePattern.FindStringSubmatchIndex(text); m != nil {
+		p.Postcode = text[m[4]:m[5]]
+		text = strings.TrimSpace(text[:m[4]] + " " + text[m[5]:])
+	}
+
+	rest := text
+	province, rest := matchPrefix(d.provinces, rest)
+	var city *Region
+	if province != nil {
+		city, rest = matchPrefix(province.Children, rest)
+		// Municipalities are often written without repeating the city.
+		if city == nil && len(province.Children) == 1 && province.Children[0].Name == province.Name {
+			city = province.Children[0]
+		}
+	} else {
+		// Addresses often start with the city.
+		for _, prov := range d.provinces {
+			if city, rest = matchPrefix(prov.Children, text); city != nil {
+				province = prov
+				break
+			}
+		}
+		rest = strings.TrimSpace(rest)
+		if city == nil {
+			rest = text
+		}
+	}
+	var district *Region
+	if city != nil {
+		district, rest = matchPrefix(city.Children, rest)
+	}
+
+	if province == nil {
+		// Nothing recognized; keep the whole text as the street.
+		p.Street = text
+		return p
+	}
+	p.Province, p.ProvinceCode = province.Name, province.Code
+	if city != nil {
+		p.City, p.CityCode = city.Name, city.Code
+	}
+	if district != nil {
+		p.District, p.DistrictCode = district.Name, district.Code
+	}
+	if m := streetPattern.FindStringSubmatch(rest); m != nil {
+		p.Street, p.Building = strings.TrimSpace(m[1]), strings.TrimSpace(m[2])
+	} else {
+		p.Street = rest
+	}
+	return p
+}
+
+// matchPrefix finds the region whose full or short name starts text and
+// returns it with the remaining text. Full names are preferred, so 北京市
+// is not read as 北京 followed by 市.
+func matchPrefix(regions []*Region, text string) (*Region, string) {
+	for _, r := range regions {
+		if rest, ok := strings.CutPrefix(text, r.Name); ok {
+			return r, strings.TrimSpace(rest)
+		}
+	}
+	for _, r := range regions {
+		if short := shortName(r.Name); short != r.Name {
+			if rest, ok := strings.CutPrefix(text, short); ok {
+				return r, strings.TrimSpace(rest)
+			}
+		}
+	}
+	return nil, text
+}
+
+// Format renders an address on one line as printed on shipping labels. The
+// city of a municipality is not repeated.
+func Format(p Place, street, building string) string {
+	city := p.City
+	if city == p.Province {
+		city = ""
+	}
+	return p.Province + city + p.District + street + building
+}
@@ -0,0 +1,219 @@
+// Package region validates addresses against the administrative divisions
+// of China, identified by their GB/T 2260 codes.
+package region
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// bundled covers all provinces, their major cities and the districts of the
+// largest ones. Levels missing from the dataset are not checked, so a full
+// dataset in the same format can be configured where needed.
+//
+//go:embed regions.json
+var bundled []byte
+
+// Levels of the hierarchy.
+const (
+	LevelProvince = iota
+	LevelCity
+	LevelDistrict
+)
+
+var (
+	ErrUnknownRegion  = errors.New("unknown region")
+	ErrRegionMismatch = errors.New("regions do not belong together")
+
+	codePattern = regexp.MustCompile(`^[1-9][0-9]{5}$`)
+)
+
+// Region is one administrative division.
+type Region struct {
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	Children []*Region `json:"children,omitempty"`
+
+	parent *Region
+	level  int
+}
+
+// Parent returns the enclosing region, or nil for provinces.
+func (r *Region) Parent() *Region {
+	return r.parent
+}
+
+// Level is one of LevelProvince, LevelCity and LevelDistrict.
+func (r *Region) Level() int {
+	return r.level
+}
+
+// Dataset is a region hierarchy.
+type Dataset struct {
+	provinces []*Region
+	byCode    map[string]*Region
+}
+
+// Load reads a dataset from a JSON file, or returns the bundled dataset when
+// path is empty.
+func Load(path string) (*Dataset, error) {
+	data := bundled
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	var provinces []*Region
+	if err := json.Unmarshal(data, &provinces); err != nil {
+		return nil, fmt.Errorf("parse region dataset: %w", err)
+	}
+	d := &Dataset{provinces: provinces, byCode: make(map[string]*Region)}
+	if err := d.index(provinces, nil); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Dataset) index(regions []*Region, parent *Region) error {
+	for _, r := range regions {
+		r.parent = parent
+		if parent != nil {
+			r.level = parent.level + 1
+		}
+		if r.level > LevelDistrict {
+			return fmt.Errorf("region %s: too deeply nested", r.Code)
+		}
+		if !codePattern.MatchString(r.Code) || r.Name == "" {
+			return fmt.Errorf("region %q: invalid code or name", r.Code)
+		}
+		if _, ok := d.byCode[r.Code]; ok {
+			return fmt.Errorf("region %s is defined twice", r.Code)
+		}
+		d.byCode[r.Code] = r
+		if err := d.index(r.Children, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lookup returns the region with the code, or nil.
+func (d *Dataset) Lookup(code string) *Region {
+	return d.byCode[code]
+}
+
+// Place is a location within the hierarchy. Codes are empty for levels the
+// dataset does not cover.
+type Place struct {
+	ProvinceCode string
+	Province     string
+	CityCode     string
+	City         string
+	DistrictCode string
+	District     string
+}
+
+// Empty reports whether no level is set.
+func (p Place) Empty() bool {
+	return p == Place{}
+}
+
+// Resolve validates a place given by codes, names or both and fills in the
+// missing ones. Codes win over names; a name given along a code must match
+// it. Names may be given in their short form, such as 北京 for 北京市.
+func (d *Dataset) Resolve(p Place) (Place, error) {
+	codes := []string{p.ProvinceCode, p.CityCode, p.DistrictCode}
+	names := []string{p.Province, p.City, p.District}
+
+	// The most specific code fixes all levels above it.
+	var resolved [3]*Region
+	for level := LevelDistrict; level >= LevelProvince; level-- {
+		if codes[level] == "" {
+			continue
+		}
+		r := d.byCode[codes[level]]
+		if r == nil || r.level != level {
+			return Place{}, fmt.Errorf("%w: %s", ErrUnknownRegion, codes[level])
+		}
+		for ; r != nil; r = r.parent {
+			if resolved[r.level] != nil && resolved[r.level] != r {
+				return Place{}, ErrRegionMismatch
+			}
+			resolved[r.level] = r
+		}
+	}
+
+	// Names resolve the levels below, and must match the levels above.
+	var out [3]string
+	for level := LevelProvince; level <= LevelDistrict; level++ {
+		name := strings.TrimSpace(names[level])
+		if r := resolved[level]; r != nil {
+			if name != "" && !matchName(r.Name, name) {
+				return Place{}, fmt.Errorf("%w: %s is not %s", ErrRegionMismatch, name, r.Name)
+			}
+			out[level] = r.Name
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		var candidates []*Region
+		if level == LevelProvince {
+			candidates = d.provinces
+		} else if parent := resolved[level-1]; parent != nil {
+			candidates = parent.Children
+		} else if out[level-1] == "" {
+			return Place{}, fmt.Errorf("%w: %s without the region above it", ErrUnknownRegion, name)
+		}
+		for _, c := range candidates {
+			if matchName(c.Name, name) {
+				resolved[level] = c
+				break
+			}
+		}
+		if resolved[level] == nil && len(candidates) > 0 {
+			return Place{}, fmt.Errorf("%w: %s", ErrUnknownRegion, name)
+		}
+		out[level] = name
+		if resolved[level] != nil {
+			out[level] = resolved[level].Name
+		}
+	}
+
+	place := Place{Province: out[0], City: out[1], District: out[2]}
+	if resolved[0] != nil {
+		place.ProvinceCode = resolved[0].Code
+	}
+	if resolved[1] != nil {
+		place.CityCode = resolved[1].Code
+	}
+	if resolved[2] != nil {
+		place.DistrictCode = resolved[2].Code
+	}
+	return place, nil
+}
+
+// suffixes are stripped to get the short form of a name, longest first.
+var suffixes = []string{"维吾尔自治区", "壮族自治区", "回族自治区", "特别行政区", "自治区", "省", "市", "区", "县"}
+
+// shortName returns the name without its administrative suffix, e.g. 广西
+// for 广西壮族自治区. Names that would get shorter than two characters are
+// kept whole.
+func shortName(name string) string {
+	for _, s := range suffixes {
+		if short, ok := strings.CutSuffix(name, s); ok && len([]rune(short)) >= 2 {
+			return short
+		}
+	}
+	return name
+}
+
+func matchName(canonical, name string) bool {
+	return name == canonical || name == shortName(canonical)
+}
@@ -0,0 +1,65 @@
+// Package sms delivers text messages to phone numbers.
+package sms
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sender delivers a text message to a phone number. Implementations for real
+// SMS gateways only have to satisfy this interface.
+type Sender interface {
+	Send(ctx context.Context, phone, message string) error
+}
+
+// New returns the sender configured by kind: "log" writes messages to the
+// service log and "file" appends them to path. Both are meant for local
+// development and tests.
+func New(kind, path string) (Sender, error) {
+	switch kind {
+	case "", "log":
+		return LogSender{}, nil
+	case "file":
+		if path == "" {
+			return nil, fmt.Errorf("sms: file sender needs a path")
+		}
+		return &FileSender{Path: path}, nil
+	default:
+		return nil, fmt.Errorf("sms: unknown sender %q", kind)
+	}
+}
+
+// LogSender writes every message to the standard logger.
+type LogSender struct{}
+
+func (LogSender) Send(ctx context.Context, phone, message string) error {
+	log.Printf("SMS to %s: %s", phone, message)
+	return nil
+}
+
+// FileSender appends every message as one line to Path, so tests can read
+// the codes that were sent.
+type FileSender struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (s *FileSender) Send(ctx context.Context, phone, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().Format(time.RFC3339), phone, message)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
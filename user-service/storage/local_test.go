@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(Config{Backend: "local", LocalDir: dir, PublicURL: "https://cdn.example.com/media/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "avatars/1/a.png", []byte("one"), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, "avatars/1/a.png", []byte("two"), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "avatars", "1", "a.png")
+	if data, err := os.ReadFile(path); err != nil || string(data) != "two" {
+		t.Fatalf("stored %q, %v", data, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Fatalf("temporary files left: %v", entries)
+	}
+	if got := s.URL("avatars/1/a.png"); got != "https://cdn.example.com/media/avatars/1/a.png" {
+		t.Errorf("URL %q", got)
+	}
+
+	if err := s.Delete(ctx, "avatars/1/a.png"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("object not deleted: %v", err)
+	}
+	if err := s.Delete(ctx, "avatars/1/a.png"); err != nil {
+		t.Fatalf("deleting a missing object: %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../x", "a/../../x", "a//b", `a\b`, "a/./b"} {
+		if err := s.Put(ctx, key, []byte("x"), "text/plain"); err == nil {
+			t.Errorf("Put accepted key %q", key)
+		}
+		if err := s.Delete(ctx, key); err == nil {
+			t.Errorf("Delete accepted key %q", key)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, cfg := range []Config{
+		{Backend: "local"},
+		{Backend: "s3", S3: S3Config{Endpoint: "http://localhost:9000"}},
+		{Backend: "ftp"},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v): no error", cfg)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is an S3 stand-in that keeps objects in memory and rejects
+// requests whose signature does not verify.
+type fakeS3 struct {
+	secret  string
+	mu      sync.Mutex
+	objects map[string]string
+	types   map[string]string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if !f.verify(r, body) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = string(body)
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verify signs a copy of r with the headers r claims to have signed and
+// compares the signatures.
+func (f *fakeS3) verify(r *http.Request, body []byte) bool {
+	auth := r.Header.Get("Authorization")
+	_, signed, ok := strings.Cut(auth, "SignedHeaders=")
+	if !ok {
+		return false
+	}
+	signed, _, _ = strings.Cut(signed, ",")
+	now, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	u := *r.URL
+	u.Scheme, u.Host = "http", r.Host
+	req, _ := http.NewRequest(r.Method, u.String(), nil)
+	for _, name := range strings.Split(signed, ";") {
+		switch name {
+		case "host", "x-amz-date", "x-amz-content-sha256":
+		default:
+			req.Header.Set(name, r.Header.Get(name))
+		}
+	}
+	signV4(req, body, "us-east-1", "AKID", f.secret, now)
+	return req.Header.Get("Authorization") == auth
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{secret: "secret", objects: map[string]string{}, types: map[string]string{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func TestS3Storage(t *testing.T) {
+	f, srv := newFakeS3(t)
+	s := NewS3Storage(S3Config{
+		Endpoint:        srv.URL,
+		Bucket:          "media",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		PathStyle:       true,
+	}, "")
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "avatars/1/a b.png", []byte("png"), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.objects["/media/avatars/1/a b.png"]; got != "png" {
+		t.Fatalf("stored objects %v", f.objects)
+	}
+	if got := f.types["/media/avatars/1/a b.png"]; got != "image/png" {
+		t.Errorf("content type %q", got)
+	}
+	if got := s.URL("avatars/1/a.png"); got != srv.URL+"/media/avatars/1/a.png" {
+		t.Errorf("URL %q", got)
+	}
+
+	if err := s.Delete(ctx, "avatars/1/a b.png"); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.objects) != 0 {
+		t.Fatalf("objects left: %v", f.objects)
+	}
+	// S3 does not distinguish deleting a missing object.
+	if err := s.Delete(ctx, "avatars/1/a b.png"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, "../x", nil, "text/plain"); err == nil {
+		t.Error("Put accepted an invalid key")
+	}
+
+	f.secret = "rotated"
+	err := s.Put(ctx, "avatars/1/a.png", []byte("png"), "image/png")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("wrong secret: got %v, want a 403 error", err)
+	}
+}
+
+func TestS3StorageURL(t *testing.T) {
+	for _, tc := range []struct {
+		cfg       S3Config
+		publicURL string
+		want      string
+	}{
+		{S3Config{Endpoint: "https://s3.amazonaws.com", Bucket: "media"}, "", "https://media.s3.amazonaws.com/a/b.png"},
+		{S3Config{Endpoint: "http://localhost:9000/", Bucket: "media", PathStyle: true}, "", "http://localhost:9000/media/a/b.png"},
+		{S3Config{Endpoint: "https://s3.amazonaws.com", Bucket: "media"}, "https://cdn.example.com/", "https://cdn.example.com/a/b.png"},
+	} {
+		if got := NewS3Storage(tc.cfg, tc.publicURL).URL("a/b.png"); got != tc.want {
+			t.Errorf("URL with %+v, %q: got %q, want %q", tc.cfg, tc.publicURL, got, tc.want)
+		}
+	}
+}
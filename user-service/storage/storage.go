@@ -0,0 +1,69 @@
+// Package storage keeps uploaded files such as avatars in a blob store.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Storage stores objects under slash-separated keys and serves them from a
+// public URL. Implementations for other blob stores only have to satisfy
+// this interface.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Delete removes the object under key. Deleting a missing object is not
+	// an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of the object under key.
+	URL(key string) string
+}
+
+// Config selects and configures a Storage.
+type Config struct {
+	// Backend is "local" or "s3".
+	Backend string
+	// PublicURL is the base URL objects are served from. For the local
+	// backend something must serve LocalDir there; for s3 it defaults to
+	// the bucket URL.
+	PublicURL string
+
+	LocalDir string
+
+	S3 S3Config
+}
+
+// New returns the storage configured by cfg.
+func New(cfg Config) (Storage, error) {
+	switch cfg.Backend {
+	case "", "local":
+		if cfg.LocalDir == "" {
+			return nil, fmt.Errorf("storage: local backend needs a directory")
+		}
+		return &LocalStorage{Dir: cfg.LocalDir, BaseURL: cfg.PublicURL}, nil
+	case "s3":
+		if cfg.S3.Endpoint == "" || cfg.S3.Bucket == "" {
+			return nil, fmt.Errorf("storage: s3 backend needs an endpoint and a bucket")
+		}
+		return NewS3Storage(cfg.S3, cfg.PublicURL), nil
+	default:
+		return nil, fmt.Errorf("storage: unknown backend %q", cfg.Backend)
+	}
+}
+
+// validKey rejects keys that could escape the storage root.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
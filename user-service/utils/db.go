@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+const mysqlDuplicateEntry = 1062
+
+// IsDuplicateEntry reports whether err is a violation of a unique index.
+// Besides the MySQL error it accepts gorm.ErrDuplicatedKey, which dialects
+// return when opened with TranslateError.
+func IsDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hasher turns passwords into self-describing hash strings that record the
+// algorithm and its parameters, so the parameters can change without
+// invalidating stored hashes.
+type Hasher interface {
+	// Hash returns the encoded hash of password.
+	Hash(password string) (string, error)
+	// Verify reports whether password matches hash, using the parameters
+	// encoded in hash.
+	Verify(password, hash string) bool
+	// Identifies reports whether hash was produced by this algorithm.
+	Identifies(hash string) bool
+	// Outdated reports whether hash uses weaker or different parameters than
+	// the hasher is configured with.
+	Outdated(hash string) bool
+}
+
+// passwordHasher hashes new passwords. verifiers check stored hashes of
+// every supported algorithm.
+var (
+	passwordHasher Hasher = NewArgon2idHasher(DefaultArgon2idParams)
+	verifiers             = []Hasher{NewArgon2idHasher(DefaultArgon2idParams), NewBcryptHasher(0)}
+)
+
+// NewPasswordHasher returns the hasher for algorithm ("argon2id" or
+// "bcrypt").
+func NewPasswordHasher(algorithm string, argon2Params Argon2idParams, bcryptCost int) (Hasher, error) {
+	switch strings.ToLower(algorithm) {
+	case "", "argon2id":
+		return NewArgon2idHasher(argon2Params), nil
+	case "bcrypt":
+		return NewBcryptHasher(bcryptCost), nil
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", algorithm)
+	}
+}
+
+// SetPasswordHasher selects the hasher for new passwords. It must be called
+// before the server starts handling requests.
+func SetPasswordHasher(h Hasher) {
+	passwordHasher = h
+}
+
+func HashPassword(password string) (string, error) {
+	return passwordHasher.Hash(password)
+}
+
+func CheckPassword(password, hash string) bool {
+	for _, v := range verifiers {
+		if v.Identifies(hash) {
+			return v.Verify(password, hash)
+		}
+	}
+	return false
+}
+
+// NeedsRehash reports whether hash should be replaced by a hash from the
+// current hasher, the next time the plain password is known.
+func NeedsRehash(hash string) bool {
+	return !passwordHasher.Identifies(hash) || passwordHasher.Outdated(hash)
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxPasswordLength bounds the work a single hash costs.
+const maxPasswordLength = 128
+
+// PasswordPolicy decides which new passwords are acceptable.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+	// denylist holds known breached passwords, lower-cased.
+	denylist map[string]struct{}
+}
+
+// LoadPasswordPolicy builds a policy. denylistFile, when set, lists one
+// breached password per line; blank lines and lines starting with # are
+// skipped.
+func LoadPasswordPolicy(minLength int, denylistFile string) (*PasswordPolicy, error) {
+	policy := &PasswordPolicy{MinLength: minLength, denylist: map[string]struct{}{}}
+	if denylistFile == "" {
+		return policy, nil
+	}
+
+	f, err := os.Open(denylistFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		policy.denylist[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", denylistFile, err)
+	}
+	return policy, nil
+}
+
+// Check returns an error describing why password is not acceptable.
+func (p *PasswordPolicy) Check(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n == 0 {
+		return errors.New("password is required")
+	}
+	if n < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+	if n > maxPasswordLength {
+		return fmt.Errorf("password must be at most %d characters", maxPasswordLength)
+	}
+	if _, ok := p.denylist[strings.ToLower(password)]; ok {
+		return errors.New("password is too common, choose another one")
+	}
+	return nil
+}
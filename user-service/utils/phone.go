@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[1-9][0-9]{6,14}$`)
+
+// NormalizePhone strips common separators from phone and reports whether the
+// result looks like a valid (E.164 style) phone number.
+func NormalizePhone(phone string) (string, bool) {
+	phone = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+	return phone, phonePattern.MatchString(phone)
+}
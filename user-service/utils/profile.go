@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxNicknameLength = 30
+	maxEmailLength    = 254
+	maxAddressLength  = 255
+	birthdayLayout    = "2006-01-02"
+)
+
+// Genders are the accepted values of the gender profile field.
+var Genders = []string{"male", "female", "other"}
+
+// ValidateNickname checks a display name.
+func ValidateNickname(nickname string) error {
+	if utf8.RuneCountInString(nickname) > maxNicknameLength {
+		return fmt.Errorf("nickname must be at most %d characters", maxNicknameLength)
+	}
+	for _, r := range nickname {
+		if unicode.IsControl(r) {
+			return errors.New("nickname must not contain control characters")
+		}
+	}
+	return nil
+}
+
+// NormalizeEmail lower-cases the domain of email and reports whether it is a
+// plain address such as "name@example.com".
+func NormalizeEmail(email string) (string, bool) {
+	email = strings.TrimSpace(email)
+	if len(email) > maxEmailLength {
+		return "", false
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || addr.Name != "" {
+		return "", false
+	}
+	at := strings.LastIndex(email, "@")
+	return email[:at] + strings.ToLower(email[at:]), true
+}
+
+// ValidGender reports whether gender is empty or one of Genders.
+func ValidGender(gender string) bool {
+	if gender == "" {
+		return true
+	}
+	for _, g := range Genders {
+		if g == gender {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseBirthday parses a YYYY-MM-DD date of birth that is not in the future.
+func ParseBirthday(birthday string) (time.Time, error) {
+	t, err := time.Parse(birthdayLayout, birthday)
+	if err != nil {
+		return time.Time{}, errors.New("birthday must be a date as YYYY-MM-DD")
+	}
+	if t.Year() < 1900 || t.After(time.Now()) {
+		return time.Time{}, errors.New("birthday is out of range")
+	}
+	return t, nil
+}
+
+// FormatBirthday formats a date of birth as YYYY-MM-DD, or "" when unset.
+func FormatBirthday(birthday *time.Time) string {
+	if birthday == nil {
+		return ""
+	}
+	return birthday.Format(birthdayLayout)
+}
+
+// ValidateAddress checks a free-form address.
+func ValidateAddress(address string) error {
+	if utf8.RuneCountInString(address) > maxAddressLength {
+		return fmt.Errorf("address must be at most %d characters", maxAddressLength)
+	}
+	return nil
+}
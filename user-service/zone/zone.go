@@ -0,0 +1,241 @@
+// Package zone decides which store delivers to a location, using delivery
+// zone polygons loaded from GeoJSON files.
+package zone
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Point is a WGS 84 coordinate.
+type Point struct {
+	Lng, Lat float64
+}
+
+// polygon is an outer ring followed by its holes.
+type polygon [][]Point
+
+// Zone is the delivery area of one store.
+type Zone struct {
+	StoreID string
+	Name    string
+	// Priority decides between overlapping zones; the highest wins.
+	Priority int
+
+	polygons []polygon
+	min, max Point
+}
+
+// Contains reports whether the point lies inside the zone. Points on the
+// edge of the zone, including the edge of a hole, belong to it.
+func (z *Zone) Contains(p Point) bool {
+	if p.Lng < z.min.Lng || p.Lng > z.max.Lng || p.Lat < z.min.Lat || p.Lat > z.max.Lat {
+		return false
+	}
+	for _, poly := range z.polygons {
+		switch ringPosition(poly[0], p) {
+		case outside:
+			continue
+		case onEdge:
+			return true
+		}
+		inHole := false
+		for _, hole := range poly[1:] {
+			if ringPosition(hole, p) == inside {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// Positions of a point relative to a ring.
+const (
+	outside = iota
+	inside
+	onEdge
+)
+
+// ringPosition uses the even-odd ray casting test, which on its own is
+// arbitrary for points on the ring, so those are detected first.
+func ringPosition(ring []Point, p Point) int {
+	in := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if onSegment(a, b, p) {
+			return onEdge
+		}
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			in = !in
+		}
+	}
+	if in {
+		return inside
+	}
+	return outside
+}
+
+// edgeTolerance absorbs rounding in the collinearity test, in squared
+// degrees; it is far below the precision of any address.
+const edgeTolerance = 1e-12
+
+func onSegment(a, b, p Point) bool {
+	cross := (b.Lng-a.Lng)*(p.Lat-a.Lat) - (b.Lat-a.Lat)*(p.Lng-a.Lng)
+	if cross > edgeTolerance || cross < -edgeTolerance {
+		return false
+	}
+	return p.Lng >= min(a.Lng, b.Lng) && p.Lng <= max(a.Lng, b.Lng) &&
+		p.Lat >= min(a.Lat, b.Lat) && p.Lat <= max(a.Lat, b.Lat)
+}
+
+// Index holds all delivery zones.
+type Index struct {
+	zones []*Zone
+}
+
+// Locate returns the zone serving the point, or nil when no store delivers
+// there.
+func (x *Index) Locate(p Point) *Zone {
+	for _, z := range x.zones {
+		if z.Contains(p) {
+			return z
+		}
+	}
+	return nil
+}
+
+// Len is the number of zones.
+func (x *Index) Len() int {
+	return len(x.zones)
+}
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Properties struct {
+		StoreID  string `json:"store_id"`
+		Name     string `json:"name"`
+		Priority int    `json:"priority"`
+	} `json:"properties"`
+	Geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+}
+
+// Load reads every *.geojson file in dir. Each file is a FeatureCollection
+// whose Polygon or MultiPolygon features carry the store_id, and optionally
+// the name and priority, of the store delivering there. An empty dir gives
+// an empty index.
+func Load(dir string) (*Index, error) {
+	x := &Index{}
+	if dir == "" {
+		return x, nil
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.geojson"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		zones, err := loadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		x.zones = append(x.zones, zones...)
+	}
+	// Stable, so files and features keep their order among equal priorities.
+	sort.SliceStable(x.zones, func(i, j int) bool {
+		return x.zones[i].Priority > x.zones[j].Priority
+	})
+	return x, nil
+}
+
+func loadFile(file string) ([]*Zone, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var fc featureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, err
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("expected a FeatureCollection, got %q", fc.Type)
+	}
+
+	var zones []*Zone
+	for i, f := range fc.Features {
+		storeID := strings.TrimSpace(f.Properties.StoreID)
+		if storeID == "" {
+			return nil, fmt.Errorf("feature %d: store_id is missing", i)
+		}
+		var raw [][][][2]float64
+		switch f.Geometry.Type {
+		case "Polygon":
+			var poly [][][2]float64
+			err = json.Unmarshal(f.Geometry.Coordinates, &poly)
+			raw = [][][][2]float64{poly}
+		case "MultiPolygon":
+			err = json.Unmarshal(f.Geometry.Coordinates, &raw)
+		default:
+			return nil, fmt.Errorf("feature %d: unsupported geometry %q", i, f.Geometry.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		z, err := newZone(storeID, f.Properties.Name, f.Properties.Priority, raw)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		zones = append(zones, z)
+	}
+	return zones, nil
+}
+
+func newZone(storeID, name string, priority int, raw [][][][2]float64) (*Zone, error) {
+	z := &Zone{StoreID: storeID, Name: name, Priority: priority}
+	first := true
+	for _, rawPoly := range raw {
+		if len(rawPoly) == 0 {
+			return nil, fmt.Errorf("empty polygon")
+		}
+		var poly polygon
+		for _, rawRing := range rawPoly {
+			if len(rawRing) < 4 {
+				return nil, fmt.Errorf("ring with fewer than 4 positions")
+			}
+			ring := make([]Point, len(rawRing))
+			for k, pos := range rawRing {
+				p := Point{Lng: pos[0], Lat: pos[1]}
+				if p.Lng < -180 || p.Lng > 180 || p.Lat < -90 || p.Lat > 90 {
+					return nil, fmt.Errorf("position %v out of range", pos)
+				}
+				ring[k] = p
+				if first {
+					z.min, z.max, first = p, p, false
+				}
+				z.min.Lng, z.min.Lat = min(z.min.Lng, p.Lng), min(z.min.Lat, p.Lat)
+				z.max.Lng, z.max.Lat = max(z.max.Lng, p.Lng), max(z.max.Lat, p.Lat)
+			}
+			poly = append(poly, ring)
+		}
+		z.polygons = append(z.polygons, poly)
+	}
+	if first {
+		return nil, fmt.Errorf("zone of store %s has no polygon", storeID)
+	}
+	return z, nil
+}
@@ -0,0 +1,99 @@
+package zone
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeZones(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLocate(t *testing.T) {
+	dir := writeZones(t, map[string]string{
+		"a.geojson": `{"type": "FeatureCollection", "features": [
+			{"properties": {"store_id": "downtown"}, "geometry": {"type": "Polygon", "coordinates": [
+				[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]],
+				[[4, 4], [6, 4], [6, 6], [4, 6], [4, 4]]
+			]}},
+			{"properties": {"store_id": "islands"}, "geometry": {"type": "MultiPolygon", "coordinates": [
+				[[[20, 0], [22, 0], [22, 2], [20, 2], [20, 0]]],
+				[[[30, 0], [32, 0], [31, 2], [30, 0]]]
+			]}}
+		]}`,
+		"b.geojson": `{"type": "FeatureCollection", "features": [
+			{"properties": {"store_id": "express", "priority": 5}, "geometry": {"type": "Polygon", "coordinates": [
+				[[8, 8], [12, 8], [12, 12], [8, 12], [8, 8]]
+			]}}
+		]}`,
+		"ignored.json": `not a zone`,
+	})
+	x, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Len() != 3 {
+		t.Fatalf("%d zones, want 3", x.Len())
+	}
+
+	for _, tc := range []struct {
+		name  string
+		point Point
+		want  string
+	}{
+		{"inside", Point{1, 1}, "downtown"},
+		{"in the hole", Point{5, 5}, ""},
+		{"on the edge of the hole", Point{4, 5}, "downtown"},
+		{"on the hole corner", Point{6, 6}, "downtown"},
+		{"on the left edge", Point{0, 5}, "downtown"},
+		{"on the right edge", Point{10, 5}, "downtown"},
+		{"on the top edge", Point{3, 10}, "downtown"},
+		{"on a corner", Point{10, 0}, "downtown"},
+		{"just outside", Point{10.000001, 5}, ""},
+		{"overlap goes to the higher priority", Point{9, 9}, "express"},
+		{"overlap edge", Point{8, 9}, "express"},
+		{"only the higher priority zone", Point{11, 11}, "express"},
+		{"first polygon of a multipolygon", Point{21, 1}, "islands"},
+		{"second polygon of a multipolygon", Point{31, 1}, "islands"},
+		{"on a slanted edge", Point{30.5, 1}, "islands"},
+		{"between the polygons", Point{25, 1}, ""},
+		{"far away", Point{-120, 45}, ""},
+	} {
+		got := ""
+		if z := x.Locate(tc.point); z != nil {
+			got = z.StoreID
+		}
+		if got != tc.want {
+			t.Errorf("%s %v: got %q, want %q", tc.name, tc.point, got, tc.want)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	square := `[[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]]`
+	for name, content := range map[string]string{
+		"not json":           `{`,
+		"not a collection":   `{"type": "Feature"}`,
+		"no store":           `{"type": "FeatureCollection", "features": [{"properties": {}, "geometry": {"type": "Polygon", "coordinates": ` + square + `}}]}`,
+		"point geometry":     `{"type": "FeatureCollection", "features": [{"properties": {"store_id": "a"}, "geometry": {"type": "Point", "coordinates": [0, 0]}}]}`,
+		"short ring":         `{"type": "FeatureCollection", "features": [{"properties": {"store_id": "a"}, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [0, 0]]]}}]}`,
+		"out of range":       `{"type": "FeatureCollection", "features": [{"properties": {"store_id": "a"}, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [200, 0], [1, 1], [0, 0]]]}}]}`,
+		"empty multipolygon": `{"type": "FeatureCollection", "features": [{"properties": {"store_id": "a"}, "geometry": {"type": "MultiPolygon", "coordinates": []}}]}`,
+	} {
+		if _, err := Load(writeZones(t, map[string]string{"z.geojson": content})); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+
+	if x, err := Load(""); err != nil || x.Len() != 0 || x.Locate(Point{0, 0}) != nil {
+		t.Errorf("no directory: %v", err)
+	}
+}